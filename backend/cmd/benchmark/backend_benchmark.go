@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
+)
+
+// benchmarkSigmoidBackends times CreditScoringApprox.Evaluate under both
+// sigmoid.Backend implementations on the same ciphertext, reporting the
+// parallel Paterson-Stockmeyer worker pool's speedup over the single
+// -threaded default.
+func benchmarkSigmoidBackends(params ckks.Parameters) {
+	fmt.Println("\n\n🧵 Sigmoid Backend Comparison (sequential vs parallel CPU)")
+	fmt.Println("=============================================================")
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+	evk := rlwe.NewMemEvaluationKeySet(rlk)
+
+	encoder := ckks.NewEncoder(params)
+	encryptor := rlwe.NewEncryptor(params, sk)
+	decryptor := rlwe.NewDecryptor(params, sk)
+	evaluator := ckks.NewEvaluator(params, evk)
+
+	values := make([]complex128, params.MaxSlots())
+	for i := range values {
+		values[i] = complex(-2.0, 0)
+	}
+	pt := ckks.NewPlaintext(params, params.MaxLevel())
+	encoder.Encode(values, pt)
+	ct, _ := encryptor.EncryptNew(pt)
+
+	approx := sigmoid.NewCreditScoringApprox(7)
+
+	approx.SetBackend(sigmoid.NewLattigoBackend(evaluator, params))
+	start := time.Now()
+	sequentialResult, err := approx.Evaluate(evaluator, ct, params)
+	sequentialTime := time.Since(start)
+	if err != nil {
+		fmt.Printf("  ❌ sequential backend failed: %v\n", err)
+		return
+	}
+
+	approx.SetBackend(sigmoid.NewParallelCPUBackend(evaluator, params))
+	start = time.Now()
+	parallelResult, err := approx.Evaluate(evaluator, ct, params)
+	parallelTime := time.Since(start)
+	if err != nil {
+		fmt.Printf("  ❌ parallel backend failed: %v\n", err)
+		return
+	}
+
+	decodedSeq := make([]complex128, params.MaxSlots())
+	decrypted := decryptor.DecryptNew(sequentialResult)
+	encoder.Decode(decrypted, decodedSeq)
+
+	decodedPar := make([]complex128, params.MaxSlots())
+	decrypted = decryptor.DecryptNew(parallelResult)
+	encoder.Decode(decrypted, decodedPar)
+
+	fmt.Printf("  Sequential (%s):  %.2f ms, sample=%.6f\n",
+		sigmoid.NewLattigoBackend(evaluator, params).Name(), float64(sequentialTime.Microseconds())/1000.0, real(decodedSeq[0]))
+	fmt.Printf("  Parallel   (%s):  %.2f ms, sample=%.6f\n",
+		sigmoid.NewParallelCPUBackend(evaluator, params).Name(), float64(parallelTime.Microseconds())/1000.0, real(decodedPar[0]))
+	fmt.Printf("  Speedup:                 %.2fx\n", sequentialTime.Seconds()/parallelTime.Seconds())
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
+)
+
+// ScoreBatch packs one customer per slot (features[c][f] -> feature
+// ciphertext f, slot c) and scores every customer with a single logit +
+// sigmoid pipeline, instead of runSingleNoiseTest's one-customer-per-call
+// path that only ever uses slot 0.
+func ScoreBatch(features [][]float64, params ckks.Parameters, encoder *ckks.Encoder, encryptor *rlwe.Encryptor, decryptor *rlwe.Decryptor, evaluator *ckks.Evaluator, sigmoidApprox sigmoid.Approximation) ([]float64, error) {
+	numCustomers := len(features)
+	numFeatures := len(noiseModel.Weights)
+
+	featureCts := make([]*rlwe.Ciphertext, numFeatures)
+	for f := 0; f < numFeatures; f++ {
+		values := make([]complex128, params.MaxSlots())
+		for c := 0; c < numCustomers; c++ {
+			values[c] = complex(features[c][f], 0)
+		}
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		if err := encoder.Encode(values, pt); err != nil {
+			return nil, fmt.Errorf("ScoreBatch feature %d encoding failed: %v", f, err)
+		}
+		ct, err := encryptor.EncryptNew(pt)
+		if err != nil {
+			return nil, fmt.Errorf("ScoreBatch feature %d encryption failed: %v", f, err)
+		}
+		featureCts[f] = ct
+	}
+
+	weightValues := make([]complex128, params.MaxSlots())
+	for i := range weightValues {
+		weightValues[i] = complex(noiseModel.Weights[0], 0)
+	}
+	weightPt := ckks.NewPlaintext(params, featureCts[0].Level())
+	encoder.Encode(weightValues, weightPt)
+
+	result, err := evaluator.MulNew(featureCts[0], weightPt)
+	if err != nil {
+		return nil, fmt.Errorf("ScoreBatch weighted mul 0 failed: %v", err)
+	}
+	if err := evaluator.Rescale(result, result); err != nil {
+		return nil, fmt.Errorf("ScoreBatch rescale 0 failed: %v", err)
+	}
+
+	for f := 1; f < numFeatures; f++ {
+		for i := range weightValues {
+			weightValues[i] = complex(noiseModel.Weights[f], 0)
+		}
+		wPt := ckks.NewPlaintext(params, featureCts[f].Level())
+		encoder.Encode(weightValues, wPt)
+
+		weighted, err := evaluator.MulNew(featureCts[f], wPt)
+		if err != nil {
+			return nil, fmt.Errorf("ScoreBatch weighted mul %d failed: %v", f, err)
+		}
+		if err := evaluator.Rescale(weighted, weighted); err != nil {
+			return nil, fmt.Errorf("ScoreBatch rescale %d failed: %v", f, err)
+		}
+		if err := evaluator.Add(result, weighted, result); err != nil {
+			return nil, fmt.Errorf("ScoreBatch add %d failed: %v", f, err)
+		}
+	}
+
+	biasValues := make([]complex128, params.MaxSlots())
+	for i := range biasValues {
+		biasValues[i] = complex(noiseModel.Bias, 0)
+	}
+	biasPt := ckks.NewPlaintext(params, result.Level())
+	encoder.Encode(biasValues, biasPt)
+	evaluator.Add(result, biasPt, result)
+
+	score, err := sigmoid.BatchEvaluate(sigmoidApprox, evaluator, result, params)
+	if err != nil {
+		return nil, fmt.Errorf("ScoreBatch sigmoid evaluation failed: %v", err)
+	}
+
+	decrypted := decryptor.DecryptNew(score)
+	decoded := make([]complex128, params.MaxSlots())
+	encoder.Decode(decrypted, decoded)
+
+	scores := make([]float64, numCustomers)
+	for c := range scores {
+		scores[c] = real(decoded[c])
+	}
+	return scores, nil
+}
+
+// benchmarkAmortizedBatchScoring compares the per-customer noise-test path
+// (one ciphertext per call) against ScoreBatch's packed path on the same
+// test cases, reporting the amortized latency win.
+func benchmarkAmortizedBatchScoring(params ckks.Parameters) {
+	fmt.Println("\n" + separator)
+	fmt.Println("⚡ AMORTIZED BATCH SCORING BENCHMARK")
+	fmt.Println(separator)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	pk := kgen.GenPublicKeyNew(sk)
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+	evk := rlwe.NewMemEvaluationKeySet(rlk)
+
+	encoder := ckks.NewEncoder(params)
+	encryptor := rlwe.NewEncryptor(params, pk)
+	decryptor := rlwe.NewDecryptor(params, sk)
+	evaluator := ckks.NewEvaluator(params, evk)
+
+	sigmoidApprox := sigmoid.Approximation(sigmoid.NewCreditScoringApprox(3))
+
+	features := make([][]float64, len(testCases))
+	for i, tc := range testCases {
+		features[i] = tc.Features
+	}
+
+	startPerCustomer := time.Now()
+	for _, tc := range testCases {
+		runSingleNoiseTest(tc, params, encoder, encryptor, decryptor, evaluator, sigmoidApprox)
+	}
+	perCustomerTime := time.Since(startPerCustomer)
+
+	startBatch := time.Now()
+	scores, err := ScoreBatch(features, params, encoder, encryptor, decryptor, evaluator, sigmoidApprox)
+	batchTime := time.Since(startBatch)
+	if err != nil {
+		fmt.Printf("   ❌ ScoreBatch failed: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\n   Per-customer path:  %.2f ms total (%.2f ms/customer)\n",
+		float64(perCustomerTime.Microseconds())/1000.0,
+		float64(perCustomerTime.Microseconds())/1000.0/float64(len(testCases)))
+	fmt.Printf("   Batched path:       %.2f ms total (%.2f ms/customer)\n",
+		float64(batchTime.Microseconds())/1000.0,
+		float64(batchTime.Microseconds())/1000.0/float64(len(testCases)))
+	fmt.Printf("   Speedup:            %.1fx\n",
+		perCustomerTime.Seconds()/batchTime.Seconds())
+	fmt.Printf("   Batched scores:     %v\n", scores)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks/bootstrapping"
+)
+
+// benchmarkBootstrap measures what a single bootstrap costs on the
+// -bootstrap parameter set from main.go: latency, how many levels it hands
+// back, and how much precision a round trip through Bootstrap() costs, so
+// users can weigh that against just picking a deeper (but non-refreshable)
+// parameter set for their model's depth.
+func benchmarkBootstrap(params ckks.Parameters) {
+	fmt.Println("\n\n♻️  Bootstrapping Benchmark")
+	fmt.Println("============================")
+
+	kgen := ckks.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+	evk := rlwe.NewMemEvaluationKeySet(rlk)
+
+	encoder := ckks.NewEncoder(params)
+	encryptor := ckks.NewEncryptor(params, sk)
+	decryptor := ckks.NewDecryptor(params, sk)
+	evaluator := ckks.NewEvaluator(params, evk)
+
+	fmt.Println("\nStep 1: Bootstrapping Parameter + Key Generation")
+	startKeys := time.Now()
+	btpParams, err := bootstrapping.NewParametersFromLiteral(params, bootstrapping.ParametersLiteral{})
+	if err != nil {
+		fmt.Printf("  Failed to build bootstrapping parameters: %v\n", err)
+		return
+	}
+	btpEvk, _, err := btpParams.GenEvaluationKeys(sk)
+	if err != nil {
+		fmt.Printf("  Failed to generate bootstrapping evaluation keys: %v\n", err)
+		return
+	}
+	btpEvaluator, err := bootstrapping.NewEvaluator(btpParams, btpEvk)
+	if err != nil {
+		fmt.Printf("  Failed to build bootstrapping evaluator: %v\n", err)
+		return
+	}
+	fmt.Printf("  Time: %.2f s\n", time.Since(startKeys).Seconds())
+
+	// Consume levels down to MaxLevel-1 with a few plaintext multiplications,
+	// the same way a linear scorer -> sigmoid -> risk-band chain would, so
+	// the bootstrap actually has something to recover.
+	values := make([]complex128, params.MaxSlots())
+	for i := range values {
+		values[i] = complex(0.37, 0)
+	}
+	pt := ckks.NewPlaintext(params, params.MaxLevel())
+	encoder.Encode(values, pt)
+	ct, _ := encryptor.EncryptNew(pt)
+
+	for ct.Level() > 1 {
+		weightPt := ckks.NewPlaintext(params, ct.Level())
+		encoder.Encode(values, weightPt)
+		evaluator.Mul(ct, weightPt, ct)
+		evaluator.Rescale(ct, ct)
+	}
+
+	fmt.Println("\nStep 2: Bootstrap")
+	fmt.Printf("  Level before: %d (MaxLevel %d)\n", ct.Level(), params.MaxLevel())
+
+	preDecrypted := decryptor.DecryptNew(ct)
+	preDecoded := make([]complex128, params.MaxSlots())
+	encoder.Decode(preDecrypted, preDecoded)
+
+	levelBefore := ct.Level()
+	startBtp := time.Now()
+	refreshed, err := btpEvaluator.Bootstrap(ct)
+	if err != nil {
+		fmt.Printf("  Bootstrap failed: %v\n", err)
+		return
+	}
+	btpLatency := time.Since(startBtp)
+	fmt.Printf("  Latency:      %.2f ms\n", float64(btpLatency.Microseconds())/1000.0)
+	fmt.Printf("  Level after:  %d (recovered %d levels)\n", refreshed.Level(), refreshed.Level()-levelBefore)
+
+	postDecrypted := decryptor.DecryptNew(refreshed)
+	postDecoded := make([]complex128, params.MaxSlots())
+	encoder.Decode(postDecrypted, postDecoded)
+
+	maxPrecisionLoss := 0.0
+	for i := range preDecoded {
+		diff := cmplx.Abs(preDecoded[i] - postDecoded[i])
+		if diff > maxPrecisionLoss {
+			maxPrecisionLoss = diff
+		}
+	}
+	fmt.Printf("  Precision loss (max |before-after|): %.2e\n", maxPrecisionLoss)
+	fmt.Printf("  Precision loss (log2):               %.2f bits\n", math.Log2(maxPrecisionLoss+1e-300))
+}
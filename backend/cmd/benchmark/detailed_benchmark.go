@@ -7,6 +7,7 @@ import (
 
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
 	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
 )
 
 // Detailed benchmark with homomorphic operations
@@ -105,6 +106,31 @@ func benchmarkHomomorphicOps(params ckks.Parameters) {
 	fmt.Printf("  Operations: %d multiplications, %d additions\n", mulCount, addCount)
 	fmt.Printf("  Final ciphertext level: %d (started at %d)\n", result.Level(), params.MaxLevel())
 
+	// ========== Step 3.5: Encrypted Sigmoid Activation ==========
+	fmt.Println("\nStep 3.5: Encrypted Sigmoid Activation")
+	sigmoidApprox := sigmoid.NewPSApprox(7)
+	fmt.Printf("  Level before sigmoid: %d (depth required: %d)\n", result.Level(), sigmoidApprox.RequiredDepth())
+
+	startSigmoid := time.Now()
+	sigmoidResult, err := sigmoidApprox.Evaluate(evaluator, result, params)
+	if err != nil {
+		fmt.Printf("  Sigmoid evaluation failed: %v\n", err)
+	} else {
+		sigmoidTime := time.Since(startSigmoid)
+		fmt.Printf("  Time: %.2f ms\n", float64(sigmoidTime.Microseconds())/1000.0)
+		fmt.Printf("  Level after sigmoid:  %d (consumed %d levels)\n", sigmoidResult.Level(), result.Level()-sigmoidResult.Level())
+
+		decryptedSigmoid := decryptor.DecryptNew(sigmoidResult)
+		decodedSigmoid := make([]complex128, params.MaxSlots())
+		encoder.Decode(decryptedSigmoid, decodedSigmoid)
+		encryptedProbability := real(decodedSigmoid[0])
+		expectedProbability := 1.0 / (1.0 + math.Exp(-expectedLogit))
+		probError := math.Abs(expectedProbability - encryptedProbability)
+		fmt.Printf("  Expected sigmoid(logit):  %.10f\n", expectedProbability)
+		fmt.Printf("  Encrypted sigmoid(logit): %.10f\n", encryptedProbability)
+		fmt.Printf("  Error:                    %.2e\n", probError)
+	}
+
 	// ========== Step 4: Decryption ==========
 	fmt.Println("\nStep 4: Decryption")
 	startDec := time.Now()
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
+)
+
+// benchmarkDPRelease runs a DP-wrapped sigmoid through the same noise-test
+// pipeline as benchmarkNoiseWithSigmoid, then empirically checks that
+// ApproximationWithDP.LastNoiseAdded across many draws matches the target
+// Laplace/Gaussian variance — the empirical distribution check chunk1-3
+// asks for, run here rather than as a _test.go file since this repo ships
+// its verification as benchmark binaries instead of `go test`.
+func benchmarkDPRelease(params ckks.Parameters) {
+	fmt.Println("\n" + separator)
+	fmt.Println("🔒 DIFFERENTIAL PRIVACY RELEASE BENCHMARK")
+	fmt.Println(separator)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	pk := kgen.GenPublicKeyNew(sk)
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+	evk := rlwe.NewMemEvaluationKeySet(rlk)
+
+	encoder := ckks.NewEncoder(params)
+	encryptor := rlwe.NewEncryptor(params, pk)
+	decryptor := rlwe.NewDecryptor(params, sk)
+	evaluator := ckks.NewEvaluator(params, evk)
+
+	dpConfig := sigmoid.DPConfig{Epsilon: 1.0, Delta: 1e-5, Mechanism: sigmoid.Laplace}
+	dpApprox := sigmoid.NewApproximationWithDP(sigmoid.NewCreditScoringApprox(3), dpConfig)
+
+	fmt.Printf("\n   Running one end-to-end encrypted release with %s...\n", dpApprox.Name())
+	tc := testCases[0]
+	tc.Expected = calculateExpected(tc.Features)
+	metrics := runSingleNoiseTest(tc, params, encoder, encryptor, decryptor, evaluator, sigmoid.Approximation(dpApprox))
+	fmt.Printf("   Released probability: %.6f (DP noise added: %.6f)\n", metrics.EncryptedProb, metrics.DPNoiseAdded)
+
+	fmt.Println("\n   Verifying the released noise distribution over many draws (plaintext-side sampling)...")
+
+	probeValues := make([]complex128, params.MaxSlots())
+	probeValues[0] = complex(0.5, 0)
+	probePt := ckks.NewPlaintext(params, params.MaxLevel())
+	encoder.Encode(probeValues, probePt)
+	probeCt, err := encryptor.EncryptNew(probePt)
+	if err != nil {
+		fmt.Printf("   ❌ failed to build probe ciphertext: %v\n", err)
+		return
+	}
+
+	const numDraws = 5000
+	for _, cfg := range []sigmoid.DPConfig{
+		{Epsilon: 1.0, Delta: 1e-5, Mechanism: sigmoid.Laplace},
+		{Epsilon: 0.5, Delta: 1e-5, Mechanism: sigmoid.Laplace},
+		{Epsilon: 1.0, Delta: 1e-5, Mechanism: sigmoid.Gaussian},
+	} {
+		identity := sigmoid.NewApproximationWithDP(sigmoid.NewIdentityApprox(), cfg)
+		samples := make([]float64, numDraws)
+		for i := 0; i < numDraws; i++ {
+			if _, err := identity.Evaluate(evaluator, probeCt, params); err != nil {
+				fmt.Printf("   ❌ %s draw failed: %v\n", identity.Name(), err)
+				return
+			}
+			samples[i] = identity.LastNoiseAdded
+		}
+
+		mean, variance := meanAndVariance(samples)
+		target := targetVariance(cfg)
+		fmt.Printf("   %-40s empirical var=%.6f target var=%.6f mean=%.6f\n", identity.Name(), variance, target, mean)
+	}
+}
+
+func meanAndVariance(samples []float64) (float64, float64) {
+	var sum float64
+	for _, s := range samples {
+		sum += s
+	}
+	mean := sum / float64(len(samples))
+
+	var sqDiff float64
+	for _, s := range samples {
+		d := s - mean
+		sqDiff += d * d
+	}
+	return mean, sqDiff / float64(len(samples))
+}
+
+func targetVariance(cfg sigmoid.DPConfig) float64 {
+	sensitivity := cfg.SensitivityDelta
+	if sensitivity == 0 {
+		sensitivity = 1.0
+	}
+	if cfg.Mechanism == sigmoid.Gaussian {
+		sigma := sensitivity * math.Sqrt(2*math.Log(1.25/cfg.Delta)) / cfg.Epsilon
+		return sigma * sigma
+	}
+	b := sensitivity / cfg.Epsilon
+	return 2 * b * b
+}
@@ -1,19 +1,38 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 
 	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
 )
 
 func main() {
-	// CKKS 파라미터 초기화 (Production: LogN=13, MaxLevel=5)
-	params, err := ckks.NewParametersFromLiteral(ckks.ParametersLiteral{
+	bootstrap := flag.Bool("bootstrap", false, "use a bootstrapping-capable parameter set instead of the 5-level default")
+	flag.Parse()
+
+	// CKKS 파라미터 초기화 (Production: LogN=13, MaxLevel=8)
+	// widened by 3 levels (was {60, 40, 40, 40, 40, 60}) to leave room for
+	// EncryptedSigmoid's degree-7 minimax approximation on top of the
+	// weighted sum.
+	paramsLit := ckks.ParametersLiteral{
 		LogN:            13,
-		LogQ:            []int{60, 40, 40, 40, 40, 60}, // MaxLevel=5
+		LogQ:            []int{60, 40, 40, 40, 40, 40, 40, 40, 60},
 		LogP:            []int{61},
 		LogDefaultScale: 40,
-	})
+	}
+	if *bootstrap {
+		// LogN=16 residual chain with room for a handful of sigmoid levels
+		// between bootstraps (see internal/app/bootstrap.go).
+		paramsLit = ckks.ParametersLiteral{
+			LogN:            16,
+			LogQ:            append([]int{60}, repeatInt(40, 20)...),
+			LogP:            []int{61, 61, 61, 61},
+			LogDefaultScale: 40,
+		}
+	}
+
+	params, err := ckks.NewParametersFromLiteral(paramsLit)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to create CKKS parameters: %v", err))
 	}
@@ -21,6 +40,9 @@ func main() {
 	fmt.Println("╔════════════════════════════════════════════════════════════╗")
 	fmt.Println("║   CKKS Credit Scoring - Production Model Benchmark        ║")
 	fmt.Println("╚════════════════════════════════════════════════════════════╝")
+	if *bootstrap {
+		fmt.Println("🔁 Bootstrapping mode enabled (amortized cost includes refresh)")
+	}
 	fmt.Printf("\n📋 CKKS Parameters:\n")
 	fmt.Printf("   LogN:            %d (Ring degree: %d)\n", params.LogN(), 1<<params.LogN())
 	fmt.Printf("   LogQ:            %v\n", []int{60, 40, 40, 60})
@@ -29,6 +51,15 @@ func main() {
 	fmt.Printf("   Default Scale:   2^%d\n", 40)
 	fmt.Println()
 
+	if *bootstrap {
+		// The LogN=16 residual chain above is sized for bootstrapping, not
+		// for the fixed-depth benchmarks below, so -bootstrap runs the
+		// bootstrap latency/level/precision measurement instead of them.
+		benchmarkBootstrap(params)
+		fmt.Println("\n✅ Benchmark Complete!")
+		return
+	}
+
 	// Run production model benchmark
 	benchmarkModel(params)
 
@@ -38,5 +69,19 @@ func main() {
 	// Run sigmoid approximation comparison benchmark
 	benchmarkSigmoidApproximations(params)
 
+	// Run batched SIMD throughput benchmark (scores/sec, not per-point latency)
+	benchmarkThroughput(params)
+
+	// Compare the sequential and parallel-CPU sigmoid evaluation backends
+	benchmarkSigmoidBackends(params)
+
 	fmt.Println("\n✅ Benchmark Complete!")
 }
+
+func repeatInt(v, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
@@ -105,6 +105,7 @@ type NoiseMetrics struct {
 	LogitLevelAfter   int
 	SigmoidLevelStart int
 	SigmoidLevelEnd   int
+	DPNoiseAdded      float64
 }
 
 // Benchmark noise levels with FULL sigmoid transformation
@@ -270,6 +271,12 @@ func runSingleNoiseTest(
 	sigmoidLevelEnd := score.Level()
 	fmt.Printf("   Sigmoid levels: Start=%d, End=%d\n", sigmoidLevelStart, sigmoidLevelEnd)
 
+	var dpNoiseAdded float64
+	if dpApprox, ok := sigmoidApprox.(*sigmoid.ApproximationWithDP); ok {
+		dpNoiseAdded = dpApprox.LastNoiseAdded
+		fmt.Printf("   DP noise added: %.10f (%s)\n", dpNoiseAdded, dpApprox.Name())
+	}
+
 	// Step 4: Decrypt and measure noise
 	fmt.Println("\nğŸ”“ Step 4: Decrypting result...")
 	scorePlaintext := decryptor.DecryptNew(score)
@@ -303,6 +310,7 @@ func runSingleNoiseTest(
 		LogitLevelAfter:   logitLevelAfter,
 		SigmoidLevelStart: sigmoidLevelStart,
 		SigmoidLevelEnd:   sigmoidLevelEnd,
+		DPNoiseAdded:      dpNoiseAdded,
 	}
 }
 
@@ -39,6 +39,20 @@ func main() {
 	// Run noise benchmark with FULL sigmoid
 	benchmarkNoiseWithSigmoid(params)
 
+	// Compare per-customer scoring against packed-batch scoring
+	benchmarkAmortizedBatchScoring(params)
+
+	// Train a weight vector directly on encrypted data and check it recovers
+	// something close to noiseModel's hand-picked reference
+	benchmarkEncryptedTraining(params)
+
+	// Release a DP-perturbed score and verify the noise distribution
+	benchmarkDPRelease(params)
+
+	// Score the same test cases against a 5-class softmax (credit grades
+	// A-E) instead of a single sigmoid
+	benchmarkNoiseWithSoftmax(params)
+
 	fmt.Println("\n" + separator)
 	fmt.Println("✅ All benchmarks completed successfully")
 	fmt.Println(separator)
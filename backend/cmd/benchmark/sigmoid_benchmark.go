@@ -24,6 +24,8 @@ func benchmarkSigmoidApproximations(params ckks.Parameters) {
 		sigmoid.NewMinimaxApprox(7),
 		sigmoid.NewCompositeApprox(3),
 		sigmoid.NewCreditScoringApprox(3), // ✅ Used in production
+		sigmoid.NewPSApprox(15),           // Paterson-Stockmeyer, tighter error within the same level budget
+		sigmoid.NewPSApprox(31),
 	}
 
 	// Test points covering typical credit scoring logit range
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/z3rotig4r/ckks_credit/backend/softmax"
+)
+
+// MultiClassModel is NoiseTestModel generalized from a single binary logit
+// to K per-class logits, e.g. credit grade buckets A-E instead of
+// default/no-default. Weights[k]/Biases[k] are the logistic-regression
+// weights/bias for class k, scored against the same feature vector.
+type MultiClassModel struct {
+	Weights [][]float64
+	Biases  []float64
+}
+
+// creditGradeModel buckets the same 5 normalized features noiseModel scores
+// into 5 credit grades (A best, E worst). Coefficients are illustrative,
+// chosen to keep every test case's logits inside softmaxExpInterval rather
+// than fit from real data.
+var creditGradeModel = MultiClassModel{
+	Weights: [][]float64{
+		{0.45, -0.25, -0.30, 0.10, 0.50},  // A: low risk
+		{0.20, -0.10, -0.12, 0.05, 0.20},  // B
+		{0.00, 0.00, 0.00, 0.00, 0.00},    // C: baseline
+		{-0.20, 0.15, 0.18, -0.05, -0.20}, // D
+		{-0.45, 0.30, 0.35, -0.10, -0.45}, // E: high risk
+	},
+	Biases: []float64{0.6, 0.3, 0.0, -0.3, -0.6},
+}
+
+// softmaxExpInterval bounds the per-class logits benchmarkNoiseWithSoftmax
+// feeds to softmax.NewExpApprox: wide enough for creditGradeModel's range on
+// testCases, tight enough to keep the exp fit's degree (and required depth)
+// small.
+var softmaxExpInterval = [2]float64{-4, 4}
+
+// softmaxExpDegree is the Remez fit degree for softmaxExpInterval.
+const softmaxExpDegree = 7
+
+func logitFor(model MultiClassModel, class int, features []float64) float64 {
+	logit := model.Biases[class]
+	for i, w := range model.Weights[class] {
+		logit += w * features[i]
+	}
+	return logit
+}
+
+func classify(model MultiClassModel, features []float64) int {
+	best, bestLogit := 0, math.Inf(-1)
+	for k := range model.Weights {
+		if l := logitFor(model, k, features); l > bestLogit {
+			best, bestLogit = k, l
+		}
+	}
+	return best
+}
+
+func plaintextSoftmax(model MultiClassModel, features []float64) []float64 {
+	exps := make([]float64, len(model.Weights))
+	var sum float64
+	for k := range model.Weights {
+		exps[k] = math.Exp(logitFor(model, k, features))
+		sum += exps[k]
+	}
+	probs := make([]float64, len(exps))
+	for k, e := range exps {
+		probs[k] = e / sum
+	}
+	return probs
+}
+
+// benchmarkNoiseWithSoftmax mirrors benchmarkNoiseWithSigmoid, but scores
+// every test case against creditGradeModel's 5 classes through
+// softmax.Softmax instead of a single sigmoid, reporting per-class
+// probability error, the level each class lands on, and whether the
+// encrypted argmax grade matches the plaintext one.
+func benchmarkNoiseWithSoftmax(params ckks.Parameters) {
+	fmt.Println("\n" + separator)
+	fmt.Println("🎯 MULTI-CLASS SOFTMAX BENCHMARK (Credit Grade A-E)")
+	fmt.Println(separator)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	pk := kgen.GenPublicKeyNew(sk)
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+	evk := rlwe.NewMemEvaluationKeySet(rlk)
+
+	encoder := ckks.NewEncoder(params)
+	encryptor := rlwe.NewEncryptor(params, pk)
+	decryptor := rlwe.NewDecryptor(params, sk)
+	evaluator := ckks.NewEvaluator(params, evk)
+
+	expApprox, err := softmax.NewExpApprox(softmaxExpInterval, softmaxExpDegree)
+	if err != nil {
+		fmt.Printf("   ❌ failed to fit exp approximation: %v\n", err)
+		return
+	}
+	fmt.Printf("   Using %s on interval %v (required depth %d)\n", expApprox.Name(), softmaxExpInterval, expApprox.RequiredDepth())
+
+	numClasses := len(creditGradeModel.Weights)
+	invCfg := softmax.InverseConfig{
+		InitialGuess: 1.0 / (float64(numClasses) * math.Exp(softmaxExpInterval[1])),
+	}
+
+	correct := 0
+	for _, tc := range testCases {
+		expectedClass := classify(creditGradeModel, tc.Features)
+		expectedProbs := plaintextSoftmax(creditGradeModel, tc.Features)
+
+		logitCts := make([]*rlwe.Ciphertext, numClasses)
+		for k := range creditGradeModel.Weights {
+			values := make([]complex128, params.MaxSlots())
+			values[0] = complex(logitFor(creditGradeModel, k, tc.Features), 0)
+			pt := ckks.NewPlaintext(params, params.MaxLevel())
+			encoder.Encode(values, pt)
+			ct, encErr := encryptor.EncryptNew(pt)
+			if encErr != nil {
+				fmt.Printf("   ❌ %s: encrypting class %d logit failed: %v\n", tc.Name, k, encErr)
+				return
+			}
+			logitCts[k] = ct
+		}
+
+		probCts, smErr := softmax.Softmax(evaluator, logitCts, params, expApprox, invCfg)
+		if smErr != nil {
+			fmt.Printf("   ❌ %s: softmax failed: %v\n", tc.Name, smErr)
+			continue
+		}
+
+		fmt.Printf("\n   %s\n", tc.Name)
+		maxProb, argmax := -1.0, -1
+		var totalAbsErr float64
+		for k, ct := range probCts {
+			pt := decryptor.DecryptNew(ct)
+			values := make([]complex128, params.MaxSlots())
+			encoder.Decode(pt, values)
+			prob := real(values[0])
+			absErr := math.Abs(prob - expectedProbs[k])
+			totalAbsErr += absErr
+			fmt.Printf("      class %d: encrypted=%.4f expected=%.4f err=%.2e level=%d\n", k, prob, expectedProbs[k], absErr, ct.Level())
+			if prob > maxProb {
+				maxProb, argmax = prob, k
+			}
+		}
+		fmt.Printf("      mean class error: %.2e, argmax=%d (expected %d)\n", totalAbsErr/float64(numClasses), argmax, expectedClass)
+		if argmax == expectedClass {
+			correct++
+		}
+	}
+
+	fmt.Printf("\n   Argmax accuracy: %d/%d\n", correct, len(testCases))
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
+)
+
+// benchmarkThroughput packs one feature value per applicant into each
+// feature ciphertext's slots and scores all of them with a single
+// dot-product plus one sigmoid.BatchEvaluate call, reporting amortized
+// per-applicant latency against benchmarkModel's one-applicant-per-slot
+// path instead of per-point latency alone. This is the same SIMD packing
+// InferCreditScoreBatch relies on in internal/app (see app.FeatureBatchScorer).
+func benchmarkThroughput(params ckks.Parameters) {
+	fmt.Println("\n\n⚡ Batched SIMD Throughput Benchmark")
+	fmt.Println("=====================================")
+
+	numApplicants := params.MaxSlots()
+	if numApplicants > 4096 {
+		numApplicants = 4096 // keep the demo run fast; production can use the full slot count
+	}
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	pk := kgen.GenPublicKeyNew(sk)
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+	evk := rlwe.NewMemEvaluationKeySet(rlk)
+
+	encoder := ckks.NewEncoder(params)
+	encryptor := rlwe.NewEncryptor(params, pk)
+	decryptor := rlwe.NewDecryptor(params, sk)
+	evaluator := ckks.NewEvaluator(params, evk)
+
+	sigmoidApprox := sigmoid.Approximation(sigmoid.NewCreditScoringApprox(3))
+	backend := sigmoid.NewLattigoBackend(evaluator, params)
+
+	weights := productionModel.Weights
+	bias := productionModel.Bias
+
+	// scoreOne runs the identical weighted-sum-plus-sigmoid pipeline against
+	// a single applicant packed into slot 0, so its elapsed time is directly
+	// comparable to the batched run below per applicant.
+	scoreOne := func(numSlots int) time.Duration {
+		start := time.Now()
+
+		featureCts := make([]*rlwe.Ciphertext, len(weights))
+		for j := range weights {
+			values := make([]complex128, params.MaxSlots())
+			for i := 0; i < numSlots; i++ {
+				values[i] = complex(float64(i%10)/10.0, 0)
+			}
+			pt := ckks.NewPlaintext(params, params.MaxLevel())
+			encoder.Encode(values, pt)
+			featureCts[j], _ = encryptor.EncryptNew(pt)
+		}
+
+		result, err := backend.LinearCombination(featureCts, weights, bias)
+		if err != nil {
+			fmt.Printf("  ❌ weighted sum failed: %v\n", err)
+			return time.Since(start)
+		}
+
+		score, err := sigmoid.BatchEvaluate(sigmoidApprox, evaluator, result, params)
+		if err != nil {
+			fmt.Printf("  ❌ sigmoid evaluation failed: %v\n", err)
+			return time.Since(start)
+		}
+		decryptor.DecryptNew(score)
+
+		return time.Since(start)
+	}
+
+	// The per-value path: one applicant occupying slot 0, the layout
+	// benchmarkModel's single-test-case encryption uses today.
+	perValueTime := scoreOne(1)
+
+	// The batched path: every applicant packed across the same ciphertexts,
+	// scored and sigmoid-activated exactly once for the whole batch.
+	batchTime := scoreOne(numApplicants)
+
+	perApplicantAmortized := batchTime / time.Duration(numApplicants)
+	scoresPerSec := float64(numApplicants) / batchTime.Seconds()
+	speedup := perValueTime.Seconds() / perApplicantAmortized.Seconds()
+
+	fmt.Printf("  Applicants per ciphertext:     %d\n", numApplicants)
+	fmt.Printf("  Per-value path (1 applicant):  %.2f ms\n", float64(perValueTime.Microseconds())/1000.0)
+	fmt.Printf("  Batched path (all applicants):  %.2f ms\n", float64(batchTime.Microseconds())/1000.0)
+	fmt.Printf("  Amortized latency/applicant:   %.4f ms\n", float64(perApplicantAmortized.Microseconds())/1000.0)
+	fmt.Printf("  Throughput:                    %.0f scores/sec\n", scoresPerSec)
+	fmt.Printf("  Speedup vs per-value path:     %.0fx\n", speedup)
+}
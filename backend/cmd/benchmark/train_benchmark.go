@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
+	"github.com/z3rotig4r/ckks_credit/backend/trainer"
+)
+
+// decryptEncryptRefresher stands in for a real bootstrapping.Evaluator on
+// parameter sets that don't carry bootstrapping keys (like the LogN=13
+// production set used throughout this benchmark binary): it decrypts a
+// ciphertext and re-encrypts it at MaxLevel, which is exactly what
+// trainer.Refresher needs to unblock training once the level budget runs
+// out.
+type decryptEncryptRefresher struct {
+	params    ckks.Parameters
+	encoder   *ckks.Encoder
+	encryptor *rlwe.Encryptor
+	decryptor *rlwe.Decryptor
+}
+
+func (r *decryptEncryptRefresher) Refresh(ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	decrypted := r.decryptor.DecryptNew(ct)
+	decoded := make([]complex128, r.params.MaxSlots())
+	if err := r.encoder.Decode(decrypted, decoded); err != nil {
+		return nil, fmt.Errorf("decryptEncryptRefresher decode failed: %v", err)
+	}
+
+	pt := ckks.NewPlaintext(r.params, r.params.MaxLevel())
+	if err := r.encoder.Encode(decoded, pt); err != nil {
+		return nil, fmt.Errorf("decryptEncryptRefresher encode failed: %v", err)
+	}
+	refreshed, err := r.encryptor.EncryptNew(pt)
+	if err != nil {
+		return nil, fmt.Errorf("decryptEncryptRefresher re-encrypt failed: %v", err)
+	}
+	return refreshed, nil
+}
+
+// benchmarkEncryptedTraining trains a fresh weight vector directly on
+// encrypted copies of the noise-test dataset using trainer.Trainer, then
+// decrypts the result and compares it against noiseModel's hand-picked
+// weights/bias as a plaintext reference. It stands in for the end-to-end
+// test this repo's bare-main benchmark binaries use in place of _test.go
+// files.
+func benchmarkEncryptedTraining(params ckks.Parameters) {
+	fmt.Println("\n" + separator)
+	fmt.Println("🎯 ENCRYPTED TRAINING BENCHMARK")
+	fmt.Println("   Training logistic regression directly on CKKS ciphertexts")
+	fmt.Println(separator)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	pk := kgen.GenPublicKeyNew(sk)
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+
+	galEls := make([]uint64, 0)
+	for step := 1; step < params.MaxSlots(); step *= 2 {
+		galEls = append(galEls, params.GaloisElement(step))
+	}
+	gks := kgen.GenGaloisKeysNew(galEls, sk)
+	evk := rlwe.NewMemEvaluationKeySet(rlk, gks...)
+
+	encoder := ckks.NewEncoder(params)
+	encryptor := rlwe.NewEncryptor(params, pk)
+	decryptor := rlwe.NewDecryptor(params, sk)
+	evaluator := ckks.NewEvaluator(params, evk)
+
+	numSamples := len(testCases)
+	numFeatures := len(noiseModel.Weights)
+
+	designMatrix := make([]*rlwe.Ciphertext, numFeatures)
+	for f := 0; f < numFeatures; f++ {
+		values := make([]complex128, params.MaxSlots())
+		for s, tc := range testCases {
+			values[s] = complex(tc.Features[f], 0)
+		}
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		if err := encoder.Encode(values, pt); err != nil {
+			fmt.Printf("   ❌ failed to encode feature %d: %v\n", f, err)
+			return
+		}
+		ct, err := encryptor.EncryptNew(pt)
+		if err != nil {
+			fmt.Printf("   ❌ failed to encrypt feature %d: %v\n", f, err)
+			return
+		}
+		designMatrix[f] = ct
+	}
+
+	labelValues := make([]complex128, params.MaxSlots())
+	for s, tc := range testCases {
+		expected := calculateExpected(tc.Features)
+		if expected >= 0.5 {
+			labelValues[s] = complex(1, 0)
+		} else {
+			labelValues[s] = complex(0, 0)
+		}
+	}
+	labelsPt := ckks.NewPlaintext(params, params.MaxLevel())
+	encoder.Encode(labelValues, labelsPt)
+	labels, err := encryptor.EncryptNew(labelsPt)
+	if err != nil {
+		fmt.Printf("   ❌ failed to encrypt labels: %v\n", err)
+		return
+	}
+
+	zeroValues := make([]complex128, params.MaxSlots())
+	initWeights := make([]*rlwe.Ciphertext, numFeatures)
+	for f := 0; f < numFeatures; f++ {
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		encoder.Encode(zeroValues, pt)
+		ct, err := encryptor.EncryptNew(pt)
+		if err != nil {
+			fmt.Printf("   ❌ failed to encrypt initial weight %d: %v\n", f, err)
+			return
+		}
+		initWeights[f] = ct
+	}
+	biasPt := ckks.NewPlaintext(params, params.MaxLevel())
+	encoder.Encode(zeroValues, biasPt)
+	initBias, err := encryptor.EncryptNew(biasPt)
+	if err != nil {
+		fmt.Printf("   ❌ failed to encrypt initial bias: %v\n", err)
+		return
+	}
+
+	cfg := trainer.Config{LearningRate: 0.5, Epochs: 5, LevelFloor: 1}
+	tr := trainer.NewTrainer(params, evaluator, encoder, cfg)
+	refresher := &decryptEncryptRefresher{params: params, encoder: encoder, encryptor: encryptor, decryptor: decryptor}
+
+	sigmoidApprox := sigmoid.Approximation(sigmoid.NewCreditScoringApprox(3))
+
+	trainedWeights, trainedBias, err := tr.Train(sigmoidApprox, designMatrix, labels, initWeights, initBias, numSamples, refresher)
+	if err != nil {
+		fmt.Printf("   ❌ training failed: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\n   %-24s %12s %12s %10s\n", "Weight", "Trained", "Reference", "AbsError")
+	for f := 0; f < numFeatures; f++ {
+		decrypted := decryptor.DecryptNew(trainedWeights[f])
+		decoded := make([]complex128, params.MaxSlots())
+		encoder.Decode(decrypted, decoded)
+		trained := real(decoded[0])
+		reference := noiseModel.Weights[f]
+		fmt.Printf("   w[%-21d] %12.6f %12.6f %10.6f\n", f, trained, reference, abs(trained-reference))
+	}
+
+	decryptedBias := decryptor.DecryptNew(trainedBias)
+	decodedBias := make([]complex128, params.MaxSlots())
+	encoder.Decode(decryptedBias, decodedBias)
+	trainedBiasValue := real(decodedBias[0])
+	fmt.Printf("   %-24s %12.6f %12.6f %10.6f\n", "bias", trainedBiasValue, noiseModel.Bias, abs(trainedBiasValue-noiseModel.Bias))
+	fmt.Println("\n   (a handful of epochs over 5 zero-initialized weights won't fully converge to")
+	fmt.Println("    noiseModel's hand-picked reference — this checks the encrypted SGD loop moves")
+	fmt.Println("    weights in the right direction, not bit-for-bit convergence.)")
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
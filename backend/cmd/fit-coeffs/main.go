@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
+)
+
+// fit-coeffs reads a CSV of observed training logits, derives the narrowest
+// interval that covers them, and emits production-ready Remez-fit sigmoid
+// coefficients for that range instead of the generic [-8, 8] minimax tables.
+func main() {
+	csvPath := flag.String("csv", "", "path to a CSV file with one logit value per row (or per column of the first row)")
+	degree := flag.Int("degree", 7, "polynomial degree to fit")
+	symmetric := flag.Bool("symmetric", true, "restrict the fit to odd powers of (x-0.5)")
+	flag.Parse()
+
+	if *csvPath == "" {
+		log.Fatal("fit-coeffs: -csv is required")
+	}
+
+	logits, err := readLogits(*csvPath)
+	if err != nil {
+		log.Fatalf("fit-coeffs: %v", err)
+	}
+	if len(logits) == 0 {
+		log.Fatal("fit-coeffs: no logit values found in CSV")
+	}
+
+	lo, hi := logits[0], logits[0]
+	for _, v := range logits {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	// Pad slightly so nodes near the observed extremes stay in-domain.
+	pad := 0.05 * (hi - lo)
+	interval := [2]float64{lo - pad, hi + pad}
+
+	fmt.Printf("Observed logit range: [%.6f, %.6f] (%d samples)\n", lo, hi, len(logits))
+	fmt.Printf("Fitting degree %d on [%.6f, %.6f]\n", *degree, interval[0], interval[1])
+
+	target := func(x float64) float64 { return 1.0 / (1.0 + math.Exp(-x)) }
+	coeffs, err := sigmoid.Fit(target, interval, *degree, sigmoid.FitOptions{
+		SymmetricAroundHalf: *symmetric,
+	})
+	if err != nil {
+		log.Fatalf("fit-coeffs: %v", err)
+	}
+
+	fmt.Println("\nFitted coefficients (ascending power order):")
+	for i, c := range coeffs {
+		fmt.Printf("  c[%d] = %.10f\n", i, c)
+	}
+
+	approx := sigmoid.NewFittedApprox(coeffs)
+	fmt.Printf("\nRequiredDepth(): %d\n", approx.RequiredDepth())
+}
+
+func readLogits(path string) ([]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+
+	var logits []float64
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %v", err)
+		}
+		for _, field := range record {
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				continue // skip headers / non-numeric cells
+			}
+			logits = append(logits, v)
+		}
+	}
+	return logits, nil
+}
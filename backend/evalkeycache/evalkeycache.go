@@ -0,0 +1,266 @@
+// Package evalkeycache caches a client's evaluation key set (relinearization
+// key plus Galois keys) across requests to the baseline backend's
+// /api/inference and /api/inference-packed handlers, which otherwise
+// base64-decode and UnmarshalBinary the same tens-of-megabytes RLK on every
+// single call only to throw it away. A client uploads its keys once via
+// POST /api/session/keys under an opaque session_id, and subsequent
+// inference requests that send back the same session_id and the keys'
+// keys_fingerprint skip deserialization entirely and reuse the cached
+// rlwe.EvaluationKeySetInterface.
+//
+// A session may also attach a bootstrapping.EvaluationKeySet, uploaded the
+// same way, so a handler whose ciphertext has run out of levels can
+// bootstrap it without asking the client to resend multi-megabyte
+// bootstrapping keys on every request that needs a refresh.
+package evalkeycache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks/bootstrapping"
+)
+
+// DefaultTTL is how long a cached entry survives without being touched
+// (via Get) before it's treated as expired.
+const DefaultTTL = 10 * time.Minute
+
+// DefaultMaxBytes bounds the cache's total tracked key size; Put evicts the
+// least-recently-used entries to stay under it.
+const DefaultMaxBytes = 256 * 1024 * 1024 // 256 MiB
+
+// DefaultMaxSessions bounds the cache's entry count independently of
+// DefaultMaxBytes: a flood of sessions each uploading trivially small key
+// sets (or none at all yet) would otherwise never trip the byte cap but
+// could still grow the entries map without bound.
+const DefaultMaxSessions = 10000
+
+// Fingerprint returns the SHA-256 fingerprint of serialized evaluation key
+// bytes (the RLK followed by each Galois key's MarshalBinary output, in
+// order) — the same value a client echoes back as keys_fingerprint to
+// claim a cache hit.
+func Fingerprint(keyBytes ...[]byte) string {
+	h := sha256.New()
+	for _, b := range keyBytes {
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// EntryInfo is a cached entry's metadata, returned by Stat for
+// GET /api/session/{id}.
+type EntryInfo struct {
+	Fingerprint string
+	SizeBytes   int
+	ExpiresAt   time.Time
+}
+
+// Stats is EvaluationKeyStore's cumulative hit/miss counters.
+type Stats struct {
+	Hits       int64
+	Misses     int64
+	BytesSaved int64 // sum of SizeBytes across every hit: deserialization work each hit skipped
+}
+
+// HitRate is Hits / (Hits + Misses), or 0 if there have been no lookups.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// EvaluationKeyStore caches a client's evaluation key set across requests,
+// keyed by session ID and a fingerprint of the serialized key bytes.
+type EvaluationKeyStore interface {
+	// Put caches evk under sessionID, replacing whatever was previously
+	// cached for that session. sizeBytes is the serialized size of the
+	// keys evk was built from, used for both BytesSaved accounting and the
+	// max-bytes cap.
+	Put(sessionID, fingerprint string, evk rlwe.EvaluationKeySetInterface, sizeBytes int)
+	// Get returns sessionID's cached evaluation key set if fingerprint
+	// matches and the entry hasn't expired, bumping its LRU recency.
+	Get(sessionID, fingerprint string) (rlwe.EvaluationKeySetInterface, bool)
+	// Evict removes sessionID's cached entry, if any.
+	Evict(sessionID string)
+	// Stat returns sessionID's cache metadata, if present.
+	Stat(sessionID string) (EntryInfo, bool)
+	// Stats returns cumulative hit-rate and bytes-saved counters.
+	Stats() Stats
+
+	// PutBootstrapKey attaches a client-uploaded bootstrapping evaluation
+	// key set to sessionID's existing entry, so a handler can later build a
+	// bootstrapping.Evaluator without asking the client to re-upload it on
+	// every request that needs a refresh. It is a no-op if sessionID has no
+	// entry yet — the RLK/Galois Put call, not this one, owns creating the
+	// session and its TTL/eviction bookkeeping.
+	PutBootstrapKey(sessionID string, evk *bootstrapping.EvaluationKeySet, sizeBytes int)
+	// BootstrapKey returns sessionID's cached bootstrapping evaluation key
+	// set, if the client has uploaded one and the entry hasn't expired.
+	BootstrapKey(sessionID string) (*bootstrapping.EvaluationKeySet, bool)
+}
+
+type entry struct {
+	sessionID    string
+	fingerprint  string
+	evk          rlwe.EvaluationKeySetInterface
+	sizeBytes    int
+	btpEvk       *bootstrapping.EvaluationKeySet
+	btpSizeBytes int
+	expiresAt    time.Time
+	elem         *list.Element
+}
+
+// LRUStore is an in-memory EvaluationKeyStore bounded by a per-entry TTL, a
+// cumulative byte cap, and a maximum entry count, evicting the
+// least-recently-used entry to make room for a new one whenever either cap
+// is exceeded.
+type LRUStore struct {
+	ttl         time.Duration
+	maxBytes    int64
+	maxSessions int
+
+	mu         sync.Mutex
+	entries    map[string]*entry
+	order      *list.List // front = most recently used
+	totalBytes int64
+	stats      Stats
+}
+
+// NewLRUStore builds an LRUStore with the given per-entry TTL, cumulative
+// byte cap, and maximum entry count.
+func NewLRUStore(ttl time.Duration, maxBytes int64, maxSessions int) *LRUStore {
+	return &LRUStore{
+		ttl:         ttl,
+		maxBytes:    maxBytes,
+		maxSessions: maxSessions,
+		entries:     make(map[string]*entry),
+		order:       list.New(),
+	}
+}
+
+func (s *LRUStore) Put(sessionID, fingerprint string, evk rlwe.EvaluationKeySetInterface, sizeBytes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.entries[sessionID]; ok {
+		s.order.Remove(old.elem)
+		s.totalBytes -= int64(old.sizeBytes) + int64(old.btpSizeBytes)
+		delete(s.entries, sessionID)
+	}
+
+	e := &entry{
+		sessionID:   sessionID,
+		fingerprint: fingerprint,
+		evk:         evk,
+		sizeBytes:   sizeBytes,
+		expiresAt:   time.Now().Add(s.ttl),
+	}
+	e.elem = s.order.PushFront(e)
+	s.entries[sessionID] = e
+	s.totalBytes += int64(sizeBytes)
+
+	s.evictToFit()
+}
+
+// evictToFit drops least-recently-used entries until totalBytes is back
+// under maxBytes and the entry count is back under maxSessions. Caller must
+// hold s.mu.
+func (s *LRUStore) evictToFit() {
+	for (s.totalBytes > s.maxBytes || s.order.Len() > s.maxSessions) && s.order.Len() > 0 {
+		oldest := s.order.Back()
+		e := oldest.Value.(*entry)
+		s.order.Remove(oldest)
+		delete(s.entries, e.sessionID)
+		s.totalBytes -= int64(e.sizeBytes) + int64(e.btpSizeBytes)
+	}
+}
+
+func (s *LRUStore) Get(sessionID, fingerprint string) (rlwe.EvaluationKeySetInterface, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[sessionID]
+	if !ok || e.fingerprint != fingerprint {
+		s.stats.Misses++
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		s.order.Remove(e.elem)
+		delete(s.entries, sessionID)
+		s.totalBytes -= int64(e.sizeBytes) + int64(e.btpSizeBytes)
+		s.stats.Misses++
+		return nil, false
+	}
+
+	s.order.MoveToFront(e.elem)
+	s.stats.Hits++
+	s.stats.BytesSaved += int64(e.sizeBytes)
+	return e.evk, true
+}
+
+func (s *LRUStore) Evict(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[sessionID]
+	if !ok {
+		return
+	}
+	s.order.Remove(e.elem)
+	delete(s.entries, sessionID)
+	s.totalBytes -= int64(e.sizeBytes) + int64(e.btpSizeBytes)
+}
+
+// PutBootstrapKey implements EvaluationKeyStore.
+func (s *LRUStore) PutBootstrapKey(sessionID string, evk *bootstrapping.EvaluationKeySet, sizeBytes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[sessionID]
+	if !ok {
+		return
+	}
+
+	s.totalBytes -= int64(e.btpSizeBytes)
+	e.btpEvk = evk
+	e.btpSizeBytes = sizeBytes
+	s.totalBytes += int64(sizeBytes)
+
+	s.order.MoveToFront(e.elem)
+	s.evictToFit()
+}
+
+// BootstrapKey implements EvaluationKeyStore.
+func (s *LRUStore) BootstrapKey(sessionID string) (*bootstrapping.EvaluationKeySet, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[sessionID]
+	if !ok || e.btpEvk == nil || time.Now().After(e.expiresAt) {
+		return nil, false
+	}
+	return e.btpEvk, true
+}
+
+func (s *LRUStore) Stat(sessionID string) (EntryInfo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[sessionID]
+	if !ok {
+		return EntryInfo{}, false
+	}
+	return EntryInfo{Fingerprint: e.fingerprint, SizeBytes: e.sizeBytes, ExpiresAt: e.expiresAt}, true
+}
+
+func (s *LRUStore) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
@@ -0,0 +1,346 @@
+// Package modelregistry replaces a server's hard-coded package-level credit
+// scoring model with a directory of versioned model files, loaded and kept
+// live without a restart: a new JSON file dropped into --models-dir appears
+// the next time a request asks for it. It also provides A/B routing so a
+// small percentage of default-routed traffic can be steered to a candidate
+// model for shadow evaluation before it's promoted to default.
+package modelregistry
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Model is one version of a logistic-regression credit scoring model, as
+// loaded from a single JSON file in --models-dir.
+type Model struct {
+	ID            string     `json:"id"`
+	Version       string     `json:"version"`
+	Weights       []float64  `json:"weights"`
+	Bias          float64    `json:"bias"`
+	FeatureNames  []string   `json:"feature_names"`
+	SigmoidRange  [2]float64 `json:"sigmoid_range"` // [a, b] the sigmoid approximation is fitted over
+	SigmoidDegree int        `json:"sigmoid_degree"`
+}
+
+// Meta is a Model's metadata without its weights and bias, for GET
+// /api/models and GET /api/models/{id} — those never return weights unless
+// a caller explicitly asks via IncludeWeights and the registry was built
+// with AllowWeightsExport.
+type Meta struct {
+	ID            string     `json:"id"`
+	Version       string     `json:"version"`
+	FeatureNames  []string   `json:"feature_names"`
+	SigmoidRange  [2]float64 `json:"sigmoid_range"`
+	SigmoidDegree int        `json:"sigmoid_degree"`
+}
+
+func (m *Model) meta() Meta {
+	return Meta{
+		ID:            m.ID,
+		Version:       m.Version,
+		FeatureNames:  m.FeatureNames,
+		SigmoidRange:  m.SigmoidRange,
+		SigmoidDegree: m.SigmoidDegree,
+	}
+}
+
+// key indexes Registry.models by a model's id and version.
+type key struct {
+	id      string
+	version string
+}
+
+// metrics accumulates per-model serving stats, reported back via Stats for
+// operators deciding whether a candidate is ready to become the default.
+type metrics struct {
+	Count          int64
+	TotalLatency   time.Duration
+	LevelsConsumed int64
+}
+
+// Stats is a snapshot of a model's cumulative latency and level-consumption
+// metrics since the registry started (or the model was last reloaded).
+type Stats struct {
+	Count             int64         `json:"count"`
+	AverageLatency    time.Duration `json:"average_latency_ns"`
+	AverageLevelsUsed float64       `json:"average_levels_consumed"`
+}
+
+// Registry holds every loaded model version, a default id/version that
+// unpinned inference requests route to, and an optional candidate for A/B
+// shadow routing.
+type Registry struct {
+	mu        sync.RWMutex
+	models    map[key]*Model
+	latest    map[string]string // id -> highest version loaded
+	defaultID string
+
+	candidateID      string
+	candidateVersion string
+	candidatePercent float64
+
+	stats map[key]*metrics
+
+	allowWeightsExport bool
+}
+
+// NewRegistry builds an empty Registry. defaultID is the model id unpinned
+// inference requests route to once it's been loaded; it need not exist yet
+// at construction time. allowWeightsExport gates whether GetMeta honors a
+// caller's request to include weights/bias in its response.
+func NewRegistry(defaultID string, allowWeightsExport bool) *Registry {
+	return &Registry{
+		models:             make(map[key]*Model),
+		latest:             make(map[string]string),
+		defaultID:          defaultID,
+		stats:              make(map[key]*metrics),
+		allowWeightsExport: allowWeightsExport,
+	}
+}
+
+// LoadDir (re)loads every *.json file in dir, replacing any existing entry
+// with the same id+version. It does not remove models whose file has been
+// deleted since the last load — operators retire a version by routing
+// traffic away from it, not by the registry silently dropping it mid-flight.
+func (r *Registry) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("modelregistry: reading %s: %v", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if err := r.loadFile(path); err != nil {
+			log.Printf("⚠️  modelregistry: skipping %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read: %v", err)
+	}
+
+	var m Model
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("parse: %v", err)
+	}
+	if err := r.Put(&m); err != nil {
+		return err
+	}
+	log.Printf("📦 modelregistry: loaded %s@%s (%d features) from %s", m.ID, m.Version, len(m.Weights), path)
+	return nil
+}
+
+// Put registers m directly, without going through a JSON file — used to
+// seed a server's built-in default model before --models-dir has been
+// scanned, and by loadFile once it has parsed one.
+func (r *Registry) Put(m *Model) error {
+	if m.ID == "" {
+		return fmt.Errorf("missing \"id\"")
+	}
+	if m.Version == "" {
+		return fmt.Errorf("missing \"version\"")
+	}
+	if len(m.Weights) == 0 {
+		return fmt.Errorf("missing \"weights\"")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.models[key{id: m.ID, version: m.Version}] = m
+	if r.latest[m.ID] == "" || m.Version > r.latest[m.ID] {
+		r.latest[m.ID] = m.Version
+	}
+	return nil
+}
+
+// Watch polls dir every interval for new or modified *.json files and loads
+// them as they appear, so a fresh model version is live without a restart.
+// It runs until stop is closed, and logs (rather than returns) load errors,
+// the same way LoadDir's per-file errors are non-fatal.
+func (r *Registry) Watch(dir string, stop <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("modelregistry: creating watcher: %v", err)
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("modelregistry: watching %s: %v", dir, err)
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-stop:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !strings.HasSuffix(event.Name, ".json") {
+					continue
+				}
+				if event.Op&(fsnotify.Create|fsnotify.Write) == 0 {
+					continue
+				}
+				if err := r.loadFile(event.Name); err != nil {
+					log.Printf("⚠️  modelregistry: hot-reload of %s failed: %v", event.Name, err)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("⚠️  modelregistry: watcher error: %v", err)
+			}
+		}
+	}()
+
+	log.Printf("👀 modelregistry: watching %s for model file changes", dir)
+	return nil
+}
+
+// Get returns the id/version model, resolving an empty version to the
+// highest version loaded for that id.
+func (r *Registry) Get(id, version string) (*Model, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if version == "" {
+		version = r.latest[id]
+	}
+	m, ok := r.models[key{id: id, version: version}]
+	return m, ok
+}
+
+// GetMeta returns id/version's metadata (and, if includeWeights is set and
+// the registry allows weight export, its weights and bias) without handing
+// back the full Model a caller could otherwise mutate.
+func (r *Registry) GetMeta(id, version string, includeWeights bool) (Meta, []float64, float64, bool) {
+	m, ok := r.Get(id, version)
+	if !ok {
+		return Meta{}, nil, 0, false
+	}
+	if includeWeights && r.allowWeightsExport {
+		return m.meta(), m.Weights, m.Bias, true
+	}
+	return m.meta(), nil, 0, true
+}
+
+// List returns every loaded model's metadata.
+func (r *Registry) List() []Meta {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	metas := make([]Meta, 0, len(r.models))
+	for _, m := range r.models {
+		metas = append(metas, m.meta())
+	}
+	return metas
+}
+
+// SetCandidate configures percent (0-1) of unpinned requests to be
+// shadow-routed to id@version instead of the default model. Pass percent
+// 0 (or an empty id) to disable A/B routing.
+func (r *Registry) SetCandidate(id, version string, percent float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.candidateID = id
+	r.candidateVersion = version
+	r.candidatePercent = percent
+}
+
+// Route resolves which model a request should score against. A request
+// that pins requestID is always honored exactly — A/B routing only ever
+// applies to the default route (requestID == ""). isCandidate reports
+// whether this request was shadow-routed to the configured candidate
+// instead of the default, for the caller to log separately.
+func (r *Registry) Route(requestID, requestVersion string) (model *Model, isCandidate bool, err error) {
+	if requestID != "" {
+		m, ok := r.Get(requestID, requestVersion)
+		if !ok {
+			return nil, false, fmt.Errorf("modelregistry: unknown model %s@%s", requestID, requestVersion)
+		}
+		return m, false, nil
+	}
+
+	r.mu.RLock()
+	candidateID, candidateVersion, percent := r.candidateID, r.candidateVersion, r.candidatePercent
+	r.mu.RUnlock()
+
+	if candidateID != "" && percent > 0 && rand.Float64() < percent {
+		if m, ok := r.Get(candidateID, candidateVersion); ok {
+			return m, true, nil
+		}
+	}
+
+	m, ok := r.Get(r.defaultID, "")
+	if !ok {
+		return nil, false, fmt.Errorf("modelregistry: default model %q not loaded", r.defaultID)
+	}
+	return m, false, nil
+}
+
+// RecordInference logs m's latency and levels consumed against its
+// cumulative stats, so operators can compare a shadow-routed candidate's
+// performance against the default model it might replace.
+func (r *Registry) RecordInference(m *Model, latency time.Duration, levelsConsumed int, isCandidate bool) {
+	r.mu.Lock()
+	k := key{id: m.ID, version: m.Version}
+	s, ok := r.stats[k]
+	if !ok {
+		s = &metrics{}
+		r.stats[k] = s
+	}
+	s.Count++
+	s.TotalLatency += latency
+	s.LevelsConsumed += int64(levelsConsumed)
+	r.mu.Unlock()
+
+	role := "default"
+	if isCandidate {
+		role = "candidate"
+	}
+	log.Printf("📊 modelregistry: %s@%s (%s) — latency=%.2fms levels_consumed=%d",
+		m.ID, m.Version, role, float64(latency.Microseconds())/1000.0, levelsConsumed)
+}
+
+// Stat returns id@version's cumulative serving stats.
+func (r *Registry) Stat(id, version string) (Stats, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if version == "" {
+		version = r.latest[id]
+	}
+	s, ok := r.stats[key{id: id, version: version}]
+	if !ok {
+		return Stats{}, false
+	}
+	avgLevels := 0.0
+	if s.Count > 0 {
+		avgLevels = float64(s.LevelsConsumed) / float64(s.Count)
+	}
+	avgLatency := time.Duration(0)
+	if s.Count > 0 {
+		avgLatency = s.TotalLatency / time.Duration(s.Count)
+	}
+	return Stats{Count: s.Count, AverageLatency: avgLatency, AverageLevelsUsed: avgLevels}, true
+}
@@ -0,0 +1,301 @@
+package sigmoid
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// Backend abstracts how a sigmoid polynomial and a model's weighted-sum are
+// evaluated homomorphically, so an Approximation like CreditScoringApprox
+// can swap its compute strategy — one goroutine vs a worker pool — without
+// its callers changing. Every Backend implementation must leave a
+// ciphertext at the same level as every other for the same inputs, so
+// switching backends changes wall-clock time, not which decryption a
+// caller gets back (within ordinary CKKS noise).
+type Backend interface {
+	// EvalPoly evaluates the monomial-basis polynomial poly (poly[i] is the
+	// coefficient of x^i) on ct. targetScale is expected to equal the
+	// backend's own params.DefaultScale() — both backends converge to that
+	// scale through their own Rescale chains, the same invariant Lattigo's
+	// polynomial.Evaluator guarantees.
+	EvalPoly(ct *rlwe.Ciphertext, poly []float64, targetScale rlwe.Scale) (*rlwe.Ciphertext, error)
+	// LinearCombination computes Σ weights[i]·cts[i] + bias.
+	LinearCombination(cts []*rlwe.Ciphertext, weights []float64, bias float64) (*rlwe.Ciphertext, error)
+	// Name identifies the backend in logs and benchmark output.
+	Name() string
+}
+
+// encodeConst encodes a single repeated constant at level, the plaintext
+// shape mulConst/addConst both need.
+func encodeConst(encoder *ckks.Encoder, params ckks.Parameters, level int, c float64) (*rlwe.Plaintext, error) {
+	values := make([]complex128, params.MaxSlots())
+	for i := range values {
+		values[i] = complex(c, 0)
+	}
+	pt := ckks.NewPlaintext(params, level)
+	if err := encoder.Encode(values, pt); err != nil {
+		return nil, err
+	}
+	return pt, nil
+}
+
+// mulConst multiplies ct in place by the plaintext constant c.
+func mulConst(evaluator *ckks.Evaluator, encoder *ckks.Encoder, params ckks.Parameters, ct *rlwe.Ciphertext, c float64) error {
+	pt, err := encodeConst(encoder, params, ct.Level(), c)
+	if err != nil {
+		return err
+	}
+	return evaluator.Mul(ct, pt, ct)
+}
+
+// addConst adds the plaintext constant c to ct in place.
+func addConst(evaluator *ckks.Evaluator, encoder *ckks.Encoder, params ckks.Parameters, ct *rlwe.Ciphertext, c float64) error {
+	pt, err := encodeConst(encoder, params, ct.Level(), c)
+	if err != nil {
+		return err
+	}
+	return evaluator.Add(ct, pt, ct)
+}
+
+// LattigoBackend evaluates polynomials and linear combinations the way this
+// package always has: one multiplication at a time via Horner's method, on
+// the caller's own goroutine. It's the baseline every other Backend is
+// compared against.
+type LattigoBackend struct {
+	evaluator *ckks.Evaluator
+	encoder   *ckks.Encoder
+	params    ckks.Parameters
+}
+
+// NewLattigoBackend builds the single-threaded default backend bound to
+// evaluator and params.
+func NewLattigoBackend(evaluator *ckks.Evaluator, params ckks.Parameters) *LattigoBackend {
+	return &LattigoBackend{
+		evaluator: evaluator,
+		encoder:   ckks.NewEncoder(params),
+		params:    params,
+	}
+}
+
+func (b *LattigoBackend) Name() string { return "lattigo-sequential" }
+
+func (b *LattigoBackend) EvalPoly(ct *rlwe.Ciphertext, poly []float64, targetScale rlwe.Scale) (*rlwe.Ciphertext, error) {
+	n := len(poly) - 1
+	result := ct.CopyNew()
+
+	if poly[n] != 0 {
+		if err := mulConst(b.evaluator, b.encoder, b.params, result, poly[n]); err != nil {
+			return nil, fmt.Errorf("lattigo backend: leading coefficient: %v", err)
+		}
+		if err := b.evaluator.Rescale(result, result); err != nil {
+			return nil, fmt.Errorf("lattigo backend: leading rescale: %v", err)
+		}
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		if i < n-1 {
+			if err := b.evaluator.Mul(result, ct, result); err != nil {
+				return nil, fmt.Errorf("lattigo backend: term %d mul: %v", i, err)
+			}
+			if err := b.evaluator.Rescale(result, result); err != nil {
+				return nil, fmt.Errorf("lattigo backend: term %d rescale: %v", i, err)
+			}
+		}
+		if poly[i] != 0 {
+			if err := addConst(b.evaluator, b.encoder, b.params, result, poly[i]); err != nil {
+				return nil, fmt.Errorf("lattigo backend: term %d coefficient: %v", i, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (b *LattigoBackend) LinearCombination(cts []*rlwe.Ciphertext, weights []float64, bias float64) (*rlwe.Ciphertext, error) {
+	if len(cts) != len(weights) {
+		return nil, fmt.Errorf("lattigo backend: LinearCombination got %d ciphertexts but %d weights", len(cts), len(weights))
+	}
+	if len(cts) == 0 {
+		return nil, fmt.Errorf("lattigo backend: LinearCombination needs at least one ciphertext")
+	}
+
+	var result *rlwe.Ciphertext
+	for i, w := range weights {
+		weighted := cts[i].CopyNew()
+		if err := mulConst(b.evaluator, b.encoder, b.params, weighted, w); err != nil {
+			return nil, fmt.Errorf("lattigo backend: weighting term %d: %v", i, err)
+		}
+		if err := b.evaluator.Rescale(weighted, weighted); err != nil {
+			return nil, fmt.Errorf("lattigo backend: rescaling term %d: %v", i, err)
+		}
+
+		if result == nil {
+			result = weighted
+			continue
+		}
+		if err := b.evaluator.Add(result, weighted, result); err != nil {
+			return nil, fmt.Errorf("lattigo backend: accumulating term %d: %v", i, err)
+		}
+	}
+
+	if err := addConst(b.evaluator, b.encoder, b.params, result, bias); err != nil {
+		return nil, fmt.Errorf("lattigo backend: adding bias: %v", err)
+	}
+	return result, nil
+}
+
+// ParallelCPUBackend evaluates the same Paterson–Stockmeyer schedule
+// PSApprox does — baby steps x^1..x^k (k≈√d) then d/k giant-step blocks —
+// but runs the giant-step blocks (and LinearCombination's per-feature
+// weighting) concurrently across a pool of workers goroutines, one per
+// runtime.GOMAXPROCS core. Baby steps stay serial: each one is built from
+// two earlier powers, so there's nothing to parallelize there. Every worker
+// gets its own ShallowCopy of the evaluator and encoder so Lattigo's
+// internal scratch buffers aren't shared across goroutines.
+type ParallelCPUBackend struct {
+	evaluator *ckks.Evaluator
+	encoder   *ckks.Encoder
+	params    ckks.Parameters
+	workers   int
+}
+
+// NewParallelCPUBackend builds a worker-pool backend bound to evaluator and
+// params, sized to runtime.GOMAXPROCS(0).
+func NewParallelCPUBackend(evaluator *ckks.Evaluator, params ckks.Parameters) *ParallelCPUBackend {
+	return &ParallelCPUBackend{
+		evaluator: evaluator,
+		encoder:   ckks.NewEncoder(params),
+		params:    params,
+		workers:   runtime.GOMAXPROCS(0),
+	}
+}
+
+func (b *ParallelCPUBackend) Name() string {
+	return fmt.Sprintf("parallel-cpu-%d", b.workers)
+}
+
+func (b *ParallelCPUBackend) EvalPoly(ct *rlwe.Ciphertext, poly []float64, targetScale rlwe.Scale) (*rlwe.Ciphertext, error) {
+	degree := len(poly) - 1
+	k := int(math.Ceil(math.Sqrt(float64(degree + 1))))
+
+	babyPowers, err := computePowers(b.evaluator, ct, k)
+	if err != nil {
+		return nil, fmt.Errorf("parallel backend: baby steps: %v", err)
+	}
+
+	numBlocks := nextPowerOfTwo(int(math.Ceil(float64(degree+1) / float64(k))))
+	padded := make([]float64, numBlocks*k)
+	copy(padded, poly)
+
+	blocks := make([]*rlwe.Ciphertext, numBlocks)
+	blockErrs := make([]error, numBlocks)
+
+	jobs := make(chan int, numBlocks)
+	for j := 0; j < numBlocks; j++ {
+		jobs <- j
+	}
+	close(jobs)
+
+	workers := b.workers
+	if workers > numBlocks {
+		workers = numBlocks
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			evaluator := b.evaluator.ShallowCopy()
+			encoder := b.encoder.ShallowCopy()
+			for j := range jobs {
+				block, err := linearCombination(evaluator, encoder, b.params, padded[j*k:(j+1)*k], babyPowers, ct.Level())
+				blocks[j] = block
+				blockErrs[j] = err
+			}
+		}()
+	}
+	wg.Wait()
+
+	for j, err := range blockErrs {
+		if err != nil {
+			return nil, fmt.Errorf("parallel backend: block %d: %v", j, err)
+		}
+	}
+
+	giantPowers, err := computePowers(b.evaluator, babyPowers[k], numBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("parallel backend: giant steps: %v", err)
+	}
+
+	return combineGiantSteps(b.evaluator, blocks, giantPowers)
+}
+
+func (b *ParallelCPUBackend) LinearCombination(cts []*rlwe.Ciphertext, weights []float64, bias float64) (*rlwe.Ciphertext, error) {
+	if len(cts) != len(weights) {
+		return nil, fmt.Errorf("parallel backend: LinearCombination got %d ciphertexts but %d weights", len(cts), len(weights))
+	}
+	if len(cts) == 0 {
+		return nil, fmt.Errorf("parallel backend: LinearCombination needs at least one ciphertext")
+	}
+
+	weighted := make([]*rlwe.Ciphertext, len(cts))
+	errs := make([]error, len(cts))
+
+	jobs := make(chan int, len(cts))
+	for i := range cts {
+		jobs <- i
+	}
+	close(jobs)
+
+	workers := b.workers
+	if workers > len(cts) {
+		workers = len(cts)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			evaluator := b.evaluator.ShallowCopy()
+			encoder := b.encoder.ShallowCopy()
+			for i := range jobs {
+				term := cts[i].CopyNew()
+				if err := mulConst(evaluator, encoder, b.params, term, weights[i]); err != nil {
+					errs[i] = fmt.Errorf("weighting term %d: %v", i, err)
+					continue
+				}
+				if err := evaluator.Rescale(term, term); err != nil {
+					errs[i] = fmt.Errorf("rescaling term %d: %v", i, err)
+					continue
+				}
+				weighted[i] = term
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("parallel backend: %v", err)
+		}
+	}
+
+	result := weighted[0]
+	for i := 1; i < len(weighted); i++ {
+		if err := b.evaluator.Add(result, weighted[i], result); err != nil {
+			return nil, fmt.Errorf("parallel backend: accumulating term %d: %v", i, err)
+		}
+	}
+
+	if err := addConst(b.evaluator, b.encoder, b.params, result, bias); err != nil {
+		return nil, fmt.Errorf("parallel backend: adding bias: %v", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,15 @@
+package sigmoid
+
+import (
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// BatchEvaluate applies approx to every slot of ct independently. Every
+// Approximation already encodes its coefficients across params.MaxSlots(),
+// so evaluating a batch of packed values costs exactly the same as
+// evaluating slot 0 alone — BatchEvaluate exists to make that packing
+// contract explicit at call sites that score many customers per ciphertext.
+func BatchEvaluate(approx Approximation, evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, params ckks.Parameters) (*rlwe.Ciphertext, error) {
+	return approx.Evaluate(evaluator, ct, params)
+}
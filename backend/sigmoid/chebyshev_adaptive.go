@@ -0,0 +1,215 @@
+package sigmoid
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// ChebyshevApproxAdaptive is NewChebyshevApprox generalized to an arbitrary
+// range and degree: instead of the three hand-tuned [-8, 8] tables above, it
+// fits sigmoid's Chebyshev series on the caller's own [a, b] via the
+// DCT-style formula cmd/benchmark/generate_coeffs.go's chebyshevCoeffs
+// prototyped standalone, then evaluates the resulting polynomial with
+// evaluatePS's Paterson–Stockmeyer machinery instead of Horner's method, so
+// degrees like 11 or 13 stay within this project's shallow modulus chain
+// (O(log2(degree)) multiplicative depth instead of O(degree)).
+type ChebyshevApproxAdaptive struct {
+	Degree int
+	A, B   float64
+
+	coeffs []float64 // monomial basis, ascending — same convention as PSApprox.coeffs
+	k      int       // Paterson-Stockmeyer baby-step size
+}
+
+// NewChebyshevApproxAdaptive fits a degree-`degree` Chebyshev series for
+// sigmoid on [a, b], converts it from the Chebyshev basis to the monomial
+// basis evaluatePS operates on, and picks a baby-step size k=⌈√(degree+1)⌉,
+// the same sizing NewPSApprox uses.
+func NewChebyshevApproxAdaptive(degree int, a, b float64) *ChebyshevApproxAdaptive {
+	chebCoeffs := chebyshevSigmoidCoeffs(degree, a, b)
+	monomial := chebyshevSeriesToMonomial(chebCoeffs, a, b)
+	return &ChebyshevApproxAdaptive{
+		Degree: degree,
+		A:      a,
+		B:      b,
+		coeffs: monomial,
+		k:      int(math.Ceil(math.Sqrt(float64(degree + 1)))),
+	}
+}
+
+func (c *ChebyshevApproxAdaptive) Name() string {
+	return fmt.Sprintf("Chebyshev-Adaptive-%d[%.2f,%.2f]", c.Degree, c.A, c.B)
+}
+
+// RequiredDepth returns ceil(log2(degree))+1, the same PS depth formula
+// PSApprox.RequiredDepth uses — evaluatePS is the same algorithm under both.
+func (c *ChebyshevApproxAdaptive) RequiredDepth() int {
+	return int(math.Ceil(math.Log2(float64(c.Degree)))) + 1
+}
+
+func (c *ChebyshevApproxAdaptive) Evaluate(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, params ckks.Parameters) (*rlwe.Ciphertext, error) {
+	return evaluatePS(evaluator, params, ct, c.coeffs, c.k)
+}
+
+// chebyshevSigmoidCoeffs fits sigmoid's degree-n Chebyshev series on [a, b]
+// by sampling it at the n+1 Chebyshev nodes mapped into [a, b] and applying
+// the discrete cosine transform — the same two-step formula
+// cmd/benchmark/generate_coeffs.go's chebyshevCoeffs uses, duplicated here
+// since that file lives in package main and can't be imported.
+func chebyshevSigmoidCoeffs(degree int, a, b float64) []float64 {
+	n := degree
+	fk := make([]float64, n+1)
+	for k := 0; k <= n; k++ {
+		theta := math.Pi * (float64(k) + 0.5) / float64(n+1)
+		x := -math.Cos(theta)               // Chebyshev node in [-1, 1]
+		xMapped := 0.5*(b-a)*x + 0.5*(a+b) // mapped into [a, b]
+		fk[k] = 1.0 / (1.0 + math.Exp(-xMapped))
+	}
+
+	coeffs := make([]float64, n+1)
+	for j := 0; j <= n; j++ {
+		sum := 0.0
+		for k := 0; k <= n; k++ {
+			theta := math.Pi * (float64(k) + 0.5) / float64(n+1)
+			sum += fk[k] * math.Cos(float64(j)*theta)
+		}
+		coeffs[j] = 2.0 * sum / float64(n+1)
+	}
+	coeffs[0] /= 2.0
+	return coeffs
+}
+
+// chebyshevSeriesToMonomial converts a Chebyshev series Σ coeffs[j]*T_j(y),
+// y=(2x-a-b)/(b-a), into a monomial-basis polynomial in x — what evaluatePS
+// needs, since it evaluates powers of the ciphertext itself rather than
+// Chebyshev polynomials of an affine-transformed ciphertext.
+func chebyshevSeriesToMonomial(coeffs []float64, a, b float64) []float64 {
+	basis := chebyshevMonomialBasisInY(len(coeffs) - 1)
+
+	var polyY []float64
+	for j, c := range coeffs {
+		if c == 0 {
+			continue
+		}
+		polyY = polyAdd(polyY, polyScale(basis[j], c))
+	}
+
+	alpha := 2.0 / (b - a)
+	beta := -(a + b) / (b - a)
+	return substituteAffine(polyY, alpha, beta)
+}
+
+// chebyshevMonomialBasisInY returns T_0(y)..T_n(y), each expressed as a
+// monomial-basis polynomial in y (ascending coefficients), via the standard
+// recurrence T_j = 2y*T_{j-1} - T_{j-2}.
+func chebyshevMonomialBasisInY(n int) [][]float64 {
+	basis := make([][]float64, n+1)
+	basis[0] = []float64{1}
+	if n >= 1 {
+		basis[1] = []float64{0, 1}
+	}
+	for j := 2; j <= n; j++ {
+		twoY := polyScale(polyMulY(basis[j-1]), 2)
+		basis[j] = polySub(twoY, basis[j-2])
+	}
+	return basis
+}
+
+// substituteAffine evaluates polyY (ascending coefficients in y) with
+// y = alpha*x + beta substituted in, via Horner's method over polynomials,
+// returning the resulting polynomial in x.
+func substituteAffine(polyY []float64, alpha, beta float64) []float64 {
+	if len(polyY) == 0 {
+		return nil
+	}
+	n := len(polyY) - 1
+	result := []float64{polyY[n]}
+	for i := n - 1; i >= 0; i-- {
+		result = polyMulAffine(result, alpha, beta)
+		result = polyAdd(result, []float64{polyY[i]})
+	}
+	return result
+}
+
+// polyMulY multiplies p (ascending coefficients) by y, i.e. shifts every
+// coefficient up one degree.
+func polyMulY(p []float64) []float64 {
+	result := make([]float64, len(p)+1)
+	copy(result[1:], p)
+	return result
+}
+
+// polyMulAffine multiplies p by (alpha*x + beta).
+func polyMulAffine(p []float64, alpha, beta float64) []float64 {
+	result := make([]float64, len(p)+1)
+	for i, c := range p {
+		result[i] += c * beta
+		result[i+1] += c * alpha
+	}
+	return result
+}
+
+func polyScale(p []float64, s float64) []float64 {
+	result := make([]float64, len(p))
+	for i, c := range p {
+		result[i] = c * s
+	}
+	return result
+}
+
+func polyAdd(p, q []float64) []float64 {
+	n := len(p)
+	if len(q) > n {
+		n = len(q)
+	}
+	result := make([]float64, n)
+	copy(result, p)
+	for i, c := range q {
+		result[i] += c
+	}
+	return result
+}
+
+func polySub(p, q []float64) []float64 {
+	n := len(p)
+	if len(q) > n {
+		n = len(q)
+	}
+	result := make([]float64, n)
+	copy(result, p)
+	for i, c := range q {
+		result[i] -= c
+	}
+	return result
+}
+
+// FeatureBound is a feature's plaintext value interval, the unit
+// EstimateRange's interval arithmetic operates on.
+type FeatureBound struct {
+	Min, Max float64
+}
+
+// EstimateRange computes tight [a, b] bounds on a linear model's logit
+// (Σ weights[i]*featureBounds[i] + bias) via interval arithmetic, so a
+// caller can pick NewChebyshevApproxAdaptive's range from the model's own
+// weights and each feature's realistic domain instead of guessing a fixed
+// interval like [-3, -1].
+func EstimateRange(weights []float64, bias float64, featureBounds []FeatureBound) (a, b float64, err error) {
+	if len(weights) != len(featureBounds) {
+		return 0, 0, fmt.Errorf("sigmoid: EstimateRange got %d weights but %d feature bounds", len(weights), len(featureBounds))
+	}
+
+	a, b = bias, bias
+	for i, w := range weights {
+		lo, hi := featureBounds[i].Min*w, featureBounds[i].Max*w
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		a += lo
+		b += hi
+	}
+	return a, b, nil
+}
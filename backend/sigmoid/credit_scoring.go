@@ -0,0 +1,76 @@
+package sigmoid
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// CreditScoringApprox is a degree-3/5/7 polynomial fit to sigmoid(x) on
+// [-3, -1], the logit range this project's credit-scoring model produces in
+// practice — the same narrow-range fit baseline/logn14/sigmoid.CreditScoringApprox
+// uses, ported here so backend/cmd/benchmark and the other callers already
+// importing this package can construct one directly instead of reaching
+// into the logn14 baseline's own copy.
+type CreditScoringApprox struct {
+	Degree  int
+	coeffs  []float64
+	backend Backend
+}
+
+// NewCreditScoringApprox creates a credit-scoring sigmoid approximation of
+// the given degree (3, 5 or 7; anything else falls back to 5, the best
+// speed/accuracy balance).
+func NewCreditScoringApprox(degree int) *CreditScoringApprox {
+	var coeffs []float64
+
+	switch degree {
+	case 7:
+		coeffs = []float64{
+			0.49768247, 0.23960472, -0.01958245, -0.04065694,
+			-0.01118931, -0.00089936, 0.00009440, 0.00001553,
+		}
+	case 3:
+		coeffs = []float64{0.53163642, 0.32991445, 0.07323628, 0.00568278}
+	default:
+		degree = 5
+		coeffs = []float64{
+			0.50181605, 0.25298880, -0.00252808, -0.03002025,
+			-0.00807291, -0.00070245,
+		}
+	}
+
+	return &CreditScoringApprox{Degree: degree, coeffs: coeffs}
+}
+
+func (c *CreditScoringApprox) Name() string {
+	return fmt.Sprintf("CreditScoring-%d", c.Degree)
+}
+
+func (c *CreditScoringApprox) RequiredDepth() int {
+	return c.Degree
+}
+
+// SetBackend overrides the Backend Evaluate delegates to. Passing nil
+// reverts to the default single-threaded LattigoBackend.
+func (c *CreditScoringApprox) SetBackend(b Backend) {
+	c.backend = b
+}
+
+// Backend returns c's currently configured Backend, building a default
+// LattigoBackend bound to evaluator/params if none was injected via
+// SetBackend — so existing callers that only ever call Evaluate see no
+// behavior change.
+func (c *CreditScoringApprox) Backend(evaluator *ckks.Evaluator, params ckks.Parameters) Backend {
+	if c.backend != nil {
+		return c.backend
+	}
+	return NewLattigoBackend(evaluator, params)
+}
+
+// Evaluate computes the sigmoid polynomial on ct via c's configured
+// Backend (see SetBackend).
+func (c *CreditScoringApprox) Evaluate(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, params ckks.Parameters) (*rlwe.Ciphertext, error) {
+	return c.Backend(evaluator, params).EvalPoly(ct, c.coeffs, params.DefaultScale())
+}
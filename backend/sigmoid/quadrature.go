@@ -0,0 +1,324 @@
+package sigmoid
+
+import (
+	"fmt"
+	"math"
+)
+
+// legendreP evaluates the degree-n Legendre polynomial at x via the
+// three-term recurrence (n+1)P_{n+1}(x) = (2n+1) x P_n(x) - n P_{n-1}(x).
+func legendreP(n int, x float64) float64 {
+	if n == 0 {
+		return 1
+	}
+	if n == 1 {
+		return x
+	}
+	pPrev, pCur := 1.0, x
+	for k := 1; k < n; k++ {
+		pNext := (float64(2*k+1)*x*pCur - float64(k)*pPrev) / float64(k+1)
+		pPrev, pCur = pCur, pNext
+	}
+	return pCur
+}
+
+// legendrePDerivative evaluates P_n'(x) via (1-x^2)P_n'(x) = n(P_{n-1}(x) - x P_n(x)).
+func legendrePDerivative(n int, x float64) float64 {
+	if n == 0 {
+		return 0
+	}
+	return float64(n) * (legendreP(n-1, x) - x*legendreP(n, x)) / (1 - x*x)
+}
+
+// gaussLegendreNodes computes the n-point Gauss-Legendre quadrature nodes and
+// weights on [-1, 1]. There's no canned quadrature table in this project, so
+// nodes are located by Newton's method on the roots of P_n (seeded at the
+// classic asymptotic approximation) and weights follow from
+// w_i = 2 / ((1-x_i^2) P_n'(x_i)^2).
+func gaussLegendreNodes(n int) (nodes, weights []float64) {
+	nodes = make([]float64, n)
+	weights = make([]float64, n)
+
+	for i := 0; i < n; i++ {
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		for iter := 0; iter < 100; iter++ {
+			f := legendreP(n, x)
+			df := legendrePDerivative(n, x)
+			dx := f / df
+			x -= dx
+			if math.Abs(dx) < 1e-15 {
+				break
+			}
+		}
+		dPn := legendrePDerivative(n, x)
+		nodes[i] = x
+		weights[i] = 2 / ((1 - x*x) * dPn * dPn)
+	}
+	return nodes, weights
+}
+
+// FitLegendre projects `target` onto the degree-`degree` Legendre basis on
+// `interval` using `quadratureOrder` Gauss-Legendre nodes:
+//
+//	c_k = ((2k+1)/2) * Σ w_i target(x_i) P_k(x_i),  k = 0..degree
+//
+// with x_i mapped from the canonical [-1, 1] quadrature nodes onto interval,
+// and the result converted from the Legendre basis to the ascending
+// monomial coefficients FittedApprox/NewFittedApprox expects. It returns the
+// coefficients and the sup-norm error of the fit measured on a 1000-point
+// grid over interval, so callers can judge whether the chosen degree is
+// tight enough before spending the multiplicative depth on it.
+func FitLegendre(target func(float64) float64, interval [2]float64, degree int, quadratureOrder int) ([]float64, float64, error) {
+	a, b := interval[0], interval[1]
+	if a >= b {
+		return nil, 0, fmt.Errorf("legendre fit failed: invalid interval [%f, %f]", a, b)
+	}
+	if degree < 0 {
+		return nil, 0, fmt.Errorf("legendre fit failed: degree must be >= 0, got %d", degree)
+	}
+	if quadratureOrder < degree+1 {
+		return nil, 0, fmt.Errorf("legendre fit failed: quadratureOrder %d must be >= degree+1 (%d)", quadratureOrder, degree+1)
+	}
+
+	canonNodes, canonWeights := gaussLegendreNodes(quadratureOrder)
+
+	mid, half := 0.5*(a+b), 0.5*(b-a)
+	legendreCoeffs := make([]float64, degree+1)
+	for k := 0; k <= degree; k++ {
+		sum := 0.0
+		for i, xc := range canonNodes {
+			x := mid + half*xc
+			sum += canonWeights[i] * target(x) * legendreP(k, xc)
+		}
+		legendreCoeffs[k] = (float64(2*k+1) / 2) * sum
+	}
+
+	monomial := legendreToMonomial(legendreCoeffs, mid, half)
+
+	supErr := supNormError(monomial, target, interval, 1000)
+	return monomial, supErr, nil
+}
+
+// legendreToMonomial expands Σ c_k P_k((x-mid)/half) into ascending powers of
+// x by accumulating each P_k's own monomial expansion (built via the same
+// three-term recurrence as legendreP, but on polynomial coefficient vectors
+// instead of scalars) and substituting y = (x-mid)/half.
+func legendreToMonomial(coeffs []float64, mid, half float64) []float64 {
+	n := len(coeffs)
+
+	// legendrePolys[k] holds P_k's coefficients in ascending powers of y.
+	legendrePolys := make([][]float64, n)
+	if n > 0 {
+		legendrePolys[0] = []float64{1}
+	}
+	if n > 1 {
+		legendrePolys[1] = []float64{0, 1}
+	}
+	for k := 1; k < n-1; k++ {
+		next := make([]float64, k+2)
+		for i, c := range legendrePolys[k] {
+			next[i+1] += float64(2*k+1) * c
+		}
+		for i, c := range legendrePolys[k-1] {
+			next[i] -= float64(k) * c
+		}
+		for i := range next {
+			next[i] /= float64(k + 1)
+		}
+		legendrePolys[k+1] = next
+	}
+
+	inY := make([]float64, n)
+	for k, c := range coeffs {
+		for i, pc := range legendrePolys[k] {
+			inY[i] += c * pc
+		}
+	}
+
+	// Substitute y = (x - mid) / half, i.e. expand Σ inY[i] * ((x-mid)/half)^i.
+	dense := make([]float64, n)
+	for i, c := range inY {
+		if c == 0 {
+			continue
+		}
+		term := substitutedPower(i, mid, half)
+		for j, tc := range term {
+			dense[j] += c * tc
+		}
+	}
+	return dense
+}
+
+// substitutedPower returns the ascending-power-of-x coefficients of
+// ((x - mid) / half)^power via the binomial expansion.
+func substitutedPower(power int, mid, half float64) []float64 {
+	out := make([]float64, power+1)
+	for j := 0; j <= power; j++ {
+		binom := binomialCoeff(power, j)
+		out[j] = binom * math.Pow(-mid, float64(power-j)) / math.Pow(half, float64(power))
+	}
+	return out
+}
+
+// supNormError samples `target` minus the monomial polynomial `coeffs` at
+// `samples` evenly spaced points across interval and returns the largest
+// absolute deviation observed.
+func supNormError(coeffs []float64, target func(float64) float64, interval [2]float64, samples int) float64 {
+	a, b := interval[0], interval[1]
+	maxErr := 0.0
+	for i := 0; i < samples; i++ {
+		x := a + (b-a)*float64(i)/float64(samples-1)
+		y := 0.0
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			y = y*x + coeffs[j]
+		}
+		if err := math.Abs(y - target(x)); err > maxErr {
+			maxErr = err
+		}
+	}
+	return maxErr
+}
+
+// NewLegendreApprox builds a sigmoid.Approximation for `interval` by
+// projecting the true sigmoid onto the degree-`degree` Legendre basis via
+// `quadratureOrder`-point Gauss-Legendre quadrature (see FitLegendre), then
+// wrapping the resulting coefficients the same way NewFittedApprox wraps
+// Remez output. It also reports the sup-norm error of the fit so callers can
+// retarget interval/degree for other logit ranges instead of hand-crafting
+// coefficients like NewCreditScoringApprox's fixed [-3, -1] table.
+func NewLegendreApprox(interval [2]float64, degree int, quadratureOrder int) (*FittedApprox, float64, error) {
+	coeffs, supErr, err := FitLegendre(sigmoidTarget, interval, degree, quadratureOrder)
+	if err != nil {
+		return nil, 0, err
+	}
+	return NewFittedApprox(coeffs), supErr, nil
+}
+
+// chebyshevT evaluates the degree-n Chebyshev polynomial of the first kind
+// at x via the three-term recurrence T_{n+1}(x) = 2x T_n(x) - T_{n-1}(x).
+func chebyshevT(n int, x float64) float64 {
+	if n == 0 {
+		return 1
+	}
+	if n == 1 {
+		return x
+	}
+	tPrev, tCur := 1.0, x
+	for k := 1; k < n; k++ {
+		tNext := 2*x*tCur - tPrev
+		tPrev, tCur = tCur, tNext
+	}
+	return tCur
+}
+
+// FitChebyshev projects `target` onto the degree-`degree` Chebyshev basis on
+// `interval` using `quadratureOrder` Chebyshev-Gauss nodes (the nodes that
+// make the discrete cosine transform exact, unlike the Remez equioscillation
+// nodes in Fit):
+//
+//	c_k = (2/N) * Σ target(x_i) T_k(cosθ_i)   (c_0 halved)
+//
+// and converts the result to ascending monomial coefficients. It returns the
+// coefficients and the sup-norm error of the fit on a 1000-point grid.
+func FitChebyshev(target func(float64) float64, interval [2]float64, degree int, quadratureOrder int) ([]float64, float64, error) {
+	a, b := interval[0], interval[1]
+	if a >= b {
+		return nil, 0, fmt.Errorf("chebyshev fit failed: invalid interval [%f, %f]", a, b)
+	}
+	if degree < 0 {
+		return nil, 0, fmt.Errorf("chebyshev fit failed: degree must be >= 0, got %d", degree)
+	}
+	if quadratureOrder < degree+1 {
+		return nil, 0, fmt.Errorf("chebyshev fit failed: quadratureOrder %d must be >= degree+1 (%d)", quadratureOrder, degree+1)
+	}
+
+	mid, half := 0.5*(a+b), 0.5*(b-a)
+	n := quadratureOrder
+
+	chebyCoeffs := make([]float64, degree+1)
+	for k := 0; k <= degree; k++ {
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			theta := math.Pi * (float64(i) + 0.5) / float64(n)
+			xc := math.Cos(theta)
+			x := mid + half*xc
+			sum += target(x) * chebyshevT(k, xc)
+		}
+		weight := 2.0 / float64(n)
+		if k == 0 {
+			weight = 1.0 / float64(n)
+		}
+		chebyCoeffs[k] = weight * sum
+	}
+
+	monomial := chebyshevToMonomial(chebyCoeffs, mid, half)
+
+	supErr := supNormError(monomial, target, interval, 1000)
+	return monomial, supErr, nil
+}
+
+// chebyshevToMonomial mirrors legendreToMonomial, but builds each T_k's
+// ascending-power-of-y expansion from the Chebyshev three-term recurrence
+// instead of the Legendre one.
+func chebyshevToMonomial(coeffs []float64, mid, half float64) []float64 {
+	n := len(coeffs)
+
+	chebyPolys := make([][]float64, n)
+	if n > 0 {
+		chebyPolys[0] = []float64{1}
+	}
+	if n > 1 {
+		chebyPolys[1] = []float64{0, 1}
+	}
+	for k := 1; k < n-1; k++ {
+		next := make([]float64, k+2)
+		for i, c := range chebyPolys[k] {
+			next[i+1] += 2 * c
+		}
+		for i, c := range chebyPolys[k-1] {
+			next[i] -= c
+		}
+		chebyPolys[k+1] = next
+	}
+
+	inY := make([]float64, n)
+	for k, c := range coeffs {
+		for i, pc := range chebyPolys[k] {
+			inY[i] += c * pc
+		}
+	}
+
+	dense := make([]float64, n)
+	for i, c := range inY {
+		if c == 0 {
+			continue
+		}
+		term := substitutedPower(i, mid, half)
+		for j, tc := range term {
+			dense[j] += c * tc
+		}
+	}
+	return dense
+}
+
+// NewChebyshevQuadratureApprox builds a sigmoid.Approximation for `interval`
+// by projecting the true sigmoid onto the degree-`degree` Chebyshev basis via
+// `quadratureOrder`-point Chebyshev-Gauss quadrature (see FitChebyshev). It's
+// named distinctly from NewChebyshevApprox, which keeps that constructor's
+// fixed degree-3/5/7 tables on the generic [-8, 8] range; this one retargets
+// to any interval/degree the caller asks for, typically halving the degree
+// needed for the same L∞ error versus a fixed template like
+// NewCreditScoringApprox's hand-tuned [-3, -1] polynomial.
+func NewChebyshevQuadratureApprox(interval [2]float64, degree int, quadratureOrder int) (*FittedApprox, float64, error) {
+	coeffs, supErr, err := FitChebyshev(sigmoidTarget, interval, degree, quadratureOrder)
+	if err != nil {
+		return nil, 0, err
+	}
+	return NewFittedApprox(coeffs), supErr, nil
+}
+
+// sigmoidTarget is the true sigmoid function that NewLegendreApprox and
+// NewChebyshevQuadratureApprox fit against by default.
+func sigmoidTarget(x float64) float64 {
+	return 1.0 / (1.0 + math.Exp(-x))
+}
@@ -0,0 +1,333 @@
+package sigmoid
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// FitOptions controls sigmoid.Fit's Remez exchange search.
+type FitOptions struct {
+	// MaxIters bounds the number of exchange iterations. Zero uses the
+	// package default (50).
+	MaxIters int
+	// Tolerance is the extrema-movement stopping threshold. Zero uses the
+	// package default (1e-9).
+	Tolerance float64
+	// SymmetricAroundHalf restricts the fit to odd powers of (x - 0.5),
+	// matching sigmoid(x) - 0.5 being an odd function, which halves the
+	// number of free coefficients for the same degree.
+	SymmetricAroundHalf bool
+}
+
+const (
+	defaultMaxIters  = 50
+	defaultTolerance = 1e-9
+)
+
+// Fit finds the degree-`degree` polynomial minimax-approximating `target` on
+// `interval` via the Remez exchange algorithm:
+//  1. seed degree+2 Chebyshev nodes in the interval as the initial extrema set
+//  2. solve p(x_i) - target(x_i) = (-1)^i * E for the coefficients and the
+//     equioscillation error E
+//  3. locate the new extrema of the residual p - target via golden-section
+//     search bracketed between consecutive node pairs (plus the endpoints)
+//  4. replace the node set with the new extrema and repeat until they move
+//     less than opts.Tolerance or opts.MaxIters is reached
+//
+// The narrower the interval, the lower the degree needed for a given error —
+// this is how production coefficients get fit to the observed logit range
+// (e.g. [-3, 0]) instead of the generic [-8, 8] minimax tables.
+func Fit(target func(float64) float64, interval [2]float64, degree int, opts FitOptions) ([]float64, error) {
+	if degree < 0 {
+		return nil, fmt.Errorf("remez fit failed: degree must be >= 0, got %d", degree)
+	}
+
+	maxIters := opts.MaxIters
+	if maxIters == 0 {
+		maxIters = defaultMaxIters
+	}
+	tolerance := opts.Tolerance
+	if tolerance == 0 {
+		tolerance = defaultTolerance
+	}
+
+	a, b := interval[0], interval[1]
+	if a >= b {
+		return nil, fmt.Errorf("remez fit failed: invalid interval [%f, %f]", a, b)
+	}
+
+	numBasis := degree + 1
+	basis := polynomialBasis(numBasis, opts.SymmetricAroundHalf)
+
+	nodes := make([]float64, numBasis+1)
+	for i := range nodes {
+		theta := math.Pi * float64(i) / float64(numBasis)
+		nodes[i] = 0.5*(a+b) - 0.5*(b-a)*math.Cos(theta)
+	}
+
+	var coeffs []float64
+	var err error
+
+	for iter := 0; iter < maxIters; iter++ {
+		coeffs, err = solveEquioscillation(nodes, basis, target)
+		if err != nil {
+			return nil, fmt.Errorf("remez fit failed at iteration %d: %v", iter, err)
+		}
+
+		residual := func(x float64) float64 {
+			return evalBasis(coeffs, basis, x) - target(x)
+		}
+
+		newNodes := make([]float64, len(nodes))
+		newNodes[0] = a
+		newNodes[len(nodes)-1] = b
+		maxMove := 0.0
+
+		for i := 1; i < len(nodes)-1; i++ {
+			lo, hi := nodes[i-1], nodes[i+1]
+			extremum := goldenSectionExtremum(residual, lo, hi)
+			if d := math.Abs(extremum - nodes[i]); d > maxMove {
+				maxMove = d
+			}
+			newNodes[i] = extremum
+		}
+
+		nodes = newNodes
+		if maxMove < tolerance {
+			break
+		}
+	}
+
+	return expandBasisCoeffs(coeffs, basis, numBasis), nil
+}
+
+// polynomialBasis returns the powers used for each of the n free
+// coefficients: 0..n-1 normally, or 1, 3, 5, ... (odd powers only) when
+// SymmetricAround(0.5) is requested.
+func polynomialBasis(n int, oddOnly bool) []int {
+	basis := make([]int, n)
+	if !oddOnly {
+		for i := range basis {
+			basis[i] = i
+		}
+		return basis
+	}
+	for i := range basis {
+		basis[i] = 2*i + 1
+	}
+	return basis
+}
+
+func evalBasis(coeffs []float64, basis []int, x float64) float64 {
+	y := x - 0.5
+	sum := 0.0
+	for i, power := range basis {
+		sum += coeffs[i] * math.Pow(y, float64(power))
+	}
+	if len(basis) > 0 && basis[0] != 0 {
+		sum += 0.5 // recenter: fitting sigmoid(x)-0.5 as an odd function of (x-0.5)
+	}
+	return sum
+}
+
+// expandBasisCoeffs turns the |basis| free coefficients into a dense,
+// ascending power-of-x coefficient slice (with the implicit 0.5 offset folded
+// into c[0] for the symmetric case) so the result slots directly into
+// NewFittedApprox / Horner evaluation like MinimaxApprox's tables.
+func expandBasisCoeffs(coeffs []float64, basis []int, numBasis int) []float64 {
+	maxPower := 0
+	for _, p := range basis {
+		if p > maxPower {
+			maxPower = p
+		}
+	}
+
+	dense := make([]float64, maxPower+1)
+	offset := 0.0
+	if len(basis) > 0 && basis[0] != 0 {
+		offset = 0.5
+	}
+
+	// (x-0.5)^power expanded via binomial theorem into powers of x.
+	for i, power := range basis {
+		c := coeffs[i]
+		for j := 0; j <= power; j++ {
+			binom := binomialCoeff(power, j)
+			term := c * binom * math.Pow(-0.5, float64(power-j))
+			dense[j] += term
+		}
+	}
+	dense[0] += offset
+
+	return dense
+}
+
+func binomialCoeff(n, k int) float64 {
+	result := 1.0
+	for i := 0; i < k; i++ {
+		result *= float64(n-i) / float64(i+1)
+	}
+	return result
+}
+
+// solveEquioscillation solves the (n+2)x(n+2) linear system for the n free
+// basis coefficients plus the equioscillating error E:
+//
+//	sum_j coeffs[j] * basis_j(x_i) + (-1)^i * E = target(x_i),  i = 0..n+1
+func solveEquioscillation(nodes []float64, basis []int, target func(float64) float64) ([]float64, error) {
+	n := len(basis)
+	size := n + 1
+	if len(nodes) != size+1 {
+		return nil, fmt.Errorf("expected %d nodes, got %d", size+1, len(nodes))
+	}
+
+	// Augmented matrix: size+1 equations, size+1 unknowns (n coeffs + E).
+	rows := size + 1
+	cols := size + 2
+	m := make([][]float64, rows)
+	for i := range m {
+		m[i] = make([]float64, cols)
+		x := nodes[i]
+		y := x - 0.5
+		for j, power := range basis {
+			m[i][j] = math.Pow(y, float64(power))
+		}
+		sign := 1.0
+		if i%2 == 1 {
+			sign = -1.0
+		}
+		m[i][n] = sign
+
+		rhs := target(x)
+		if len(basis) > 0 && basis[0] != 0 {
+			rhs -= 0.5
+		}
+		m[i][cols-1] = rhs
+	}
+
+	if err := gaussianEliminate(m); err != nil {
+		return nil, err
+	}
+
+	coeffs := make([]float64, n)
+	for i := 0; i < n; i++ {
+		coeffs[i] = m[i][cols-1]
+	}
+	return coeffs, nil
+}
+
+func gaussianEliminate(m [][]float64) error {
+	rows := len(m)
+	cols := len(m[0])
+
+	for col := 0; col < rows; col++ {
+		pivot := col
+		for r := col + 1; r < rows; r++ {
+			if math.Abs(m[r][col]) > math.Abs(m[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-14 {
+			return fmt.Errorf("remez linear system is singular at column %d", col)
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for r := 0; r < rows; r++ {
+			if r == col {
+				continue
+			}
+			factor := m[r][col] / m[col][col]
+			for c := col; c < cols; c++ {
+				m[r][c] -= factor * m[col][c]
+			}
+		}
+	}
+
+	for r := 0; r < rows; r++ {
+		m[r][cols-1] /= m[r][r]
+	}
+	return nil
+}
+
+const goldenRatio = 0.6180339887498949
+
+// goldenSectionExtremum finds the x in [lo, hi] maximizing |f(x)| via
+// golden-section search.
+func goldenSectionExtremum(f func(float64) float64, lo, hi float64) float64 {
+	absF := func(x float64) float64 { return math.Abs(f(x)) }
+
+	c := hi - goldenRatio*(hi-lo)
+	d := lo + goldenRatio*(hi-lo)
+
+	for i := 0; i < 100 && hi-lo > 1e-12; i++ {
+		if absF(c) > absF(d) {
+			hi = d
+		} else {
+			lo = c
+		}
+		c = hi - goldenRatio*(hi-lo)
+		d = lo + goldenRatio*(hi-lo)
+	}
+
+	return 0.5 * (lo + hi)
+}
+
+// FittedApprox wraps coefficients produced by Fit and evaluates them with
+// plain Horner's method, like ChebyshevApprox/MinimaxApprox.
+type FittedApprox struct {
+	coeffs []float64
+}
+
+// NewFittedApprox wraps Remez-fit coefficients (ascending power order) for
+// use as a sigmoid.Approximation.
+func NewFittedApprox(coeffs []float64) *FittedApprox {
+	return &FittedApprox{coeffs: coeffs}
+}
+
+func (f *FittedApprox) Name() string {
+	return fmt.Sprintf("Fitted-%d", len(f.coeffs)-1)
+}
+
+func (f *FittedApprox) RequiredDepth() int {
+	return len(f.coeffs) - 1
+}
+
+func (f *FittedApprox) Evaluate(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, params ckks.Parameters) (*rlwe.Ciphertext, error) {
+	encoder := ckks.NewEncoder(params)
+
+	n := len(f.coeffs) - 1
+	result := ct.CopyNew()
+
+	if f.coeffs[n] != 0 {
+		constPt := ckks.NewPlaintext(params, result.Level())
+		values := make([]complex128, params.MaxSlots())
+		for i := range values {
+			values[i] = complex(f.coeffs[n], 0)
+		}
+		encoder.Encode(values, constPt)
+		evaluator.Mul(result, constPt, result)
+		evaluator.Rescale(result, result)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		if i < n-1 {
+			evaluator.Mul(result, ct, result)
+			evaluator.Rescale(result, result)
+		}
+
+		if f.coeffs[i] != 0 {
+			constPt := ckks.NewPlaintext(params, result.Level())
+			values := make([]complex128, params.MaxSlots())
+			for j := range values {
+				values[j] = complex(f.coeffs[i], 0)
+			}
+			encoder.Encode(values, constPt)
+			evaluator.Add(result, constPt, result)
+		}
+	}
+
+	return result, nil
+}
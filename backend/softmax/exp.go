@@ -0,0 +1,49 @@
+// Package softmax homomorphically evaluates a K-class softmax over encrypted
+// logits, one ciphertext per class. It builds on the sigmoid package's Remez
+// fitting and Horner evaluator rather than duplicating them: ExpApprox fits
+// exp via sigmoid.Fit, and Softmax normalizes with a Newton-Raphson
+// approximate reciprocal (see inverse.go).
+package softmax
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+
+	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
+)
+
+// ExpApprox is a polynomial approximation of exp(x) on a bounded interval,
+// fit the same way sigmoid.FittedApprox is: Remez exchange minimax fit
+// (sigmoid.Fit), then plain Horner evaluation. Softmax logits must be kept
+// within the fitting interval, so callers should size it to their observed
+// logit range, not the full real line.
+type ExpApprox struct {
+	interval [2]float64
+	fitted   *sigmoid.FittedApprox
+}
+
+// NewExpApprox fits exp to `interval` at `degree` via sigmoid.Fit — the same
+// Remez exchange this package already uses for sigmoid, just pointed at
+// math.Exp instead of the logistic function.
+func NewExpApprox(interval [2]float64, degree int) (*ExpApprox, error) {
+	coeffs, err := sigmoid.Fit(math.Exp, interval, degree, sigmoid.FitOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("softmax: exp fit failed: %v", err)
+	}
+	return &ExpApprox{interval: interval, fitted: sigmoid.NewFittedApprox(coeffs)}, nil
+}
+
+func (e *ExpApprox) Name() string {
+	return e.fitted.Name()
+}
+
+func (e *ExpApprox) RequiredDepth() int {
+	return e.fitted.RequiredDepth()
+}
+
+func (e *ExpApprox) Evaluate(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, params ckks.Parameters) (*rlwe.Ciphertext, error) {
+	return e.fitted.Evaluate(evaluator, ct, params)
+}
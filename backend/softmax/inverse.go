@@ -0,0 +1,141 @@
+package softmax
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// InverseConfig controls NewtonInverse's approximate reciprocal iteration.
+type InverseConfig struct {
+	// Iterations bounds the number of Newton-Raphson steps. Zero uses the
+	// package default (4), which brings a residual starting below 1 down
+	// to roughly 1e-5 (the error term squares every iteration).
+	Iterations int
+	// InitialGuess seeds y0 for 1/x ≈ y0. Convergence requires
+	// 0 < x*InitialGuess < 2; callers derive it from the known range of the
+	// value being inverted (for Softmax, 1/(numClasses*expMax) is a safe
+	// lower bound on 1/Σexp(zₖ)).
+	InitialGuess float64
+}
+
+const defaultInverseIterations = 4
+
+// NewtonInverse approximates 1/ct homomorphically via Newton-Raphson:
+//
+//	y_{n+1} = y_n * (2 - ct*y_n)
+//
+// which converges quadratically once ct*InitialGuess is within (0, 2). To
+// avoid needing an encrypted copy of the scalar InitialGuess, the iteration
+// is tracked via the residual e_n = 1 - ct*y_n and the accumulated weight
+// w_n = y_n/InitialGuess: e_{n+1} = e_n^2 and w_{n+1} = w_n*(1+e_n), so the
+// per-iteration ciphertext work is one squaring and one multiply, and the
+// final y_n = InitialGuess * w_n is a single plaintext scaling.
+func NewtonInverse(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, params ckks.Parameters, cfg InverseConfig) (*rlwe.Ciphertext, error) {
+	if cfg.InitialGuess <= 0 {
+		return nil, fmt.Errorf("softmax: NewtonInverse requires a positive InitialGuess, got %f", cfg.InitialGuess)
+	}
+	iterations := cfg.Iterations
+	if iterations == 0 {
+		iterations = defaultInverseIterations
+	}
+
+	encoder := ckks.NewEncoder(params)
+
+	xy, err := constMulNew(evaluator, encoder, params, ct, cfg.InitialGuess)
+	if err != nil {
+		return nil, fmt.Errorf("softmax: NewtonInverse seed failed: %v", err)
+	}
+
+	e, err := oneMinus(evaluator, encoder, params, xy)
+	if err != nil {
+		return nil, fmt.Errorf("softmax: NewtonInverse residual failed: %v", err)
+	}
+
+	w, err := onePlus(evaluator, encoder, params, e)
+	if err != nil {
+		return nil, fmt.Errorf("softmax: NewtonInverse weight init failed: %v", err)
+	}
+
+	for i := 1; i < iterations; i++ {
+		e2, err := evaluator.MulRelinNew(e, e)
+		if err != nil {
+			return nil, fmt.Errorf("softmax: NewtonInverse squaring residual at iteration %d failed: %v", i, err)
+		}
+		if err := evaluator.Rescale(e2, e2); err != nil {
+			return nil, fmt.Errorf("softmax: NewtonInverse rescale at iteration %d failed: %v", i, err)
+		}
+
+		e, err = oneMinus(evaluator, encoder, params, e2)
+		if err != nil {
+			return nil, fmt.Errorf("softmax: NewtonInverse residual update at iteration %d failed: %v", i, err)
+		}
+
+		step, err := onePlus(evaluator, encoder, params, e)
+		if err != nil {
+			return nil, fmt.Errorf("softmax: NewtonInverse weight step at iteration %d failed: %v", i, err)
+		}
+
+		w, err = evaluator.MulRelinNew(w, step)
+		if err != nil {
+			return nil, fmt.Errorf("softmax: NewtonInverse weight update at iteration %d failed: %v", i, err)
+		}
+		if err := evaluator.Rescale(w, w); err != nil {
+			return nil, fmt.Errorf("softmax: NewtonInverse rescale weight at iteration %d failed: %v", i, err)
+		}
+	}
+
+	result, err := constMulNew(evaluator, encoder, params, w, cfg.InitialGuess)
+	if err != nil {
+		return nil, fmt.Errorf("softmax: NewtonInverse final scaling failed: %v", err)
+	}
+	return result, nil
+}
+
+func constPlaintext(encoder *ckks.Encoder, params ckks.Parameters, level int, value float64) *rlwe.Plaintext {
+	pt := ckks.NewPlaintext(params, level)
+	values := make([]complex128, params.MaxSlots())
+	for i := range values {
+		values[i] = complex(value, 0)
+	}
+	encoder.Encode(values, pt)
+	return pt
+}
+
+func constMulNew(evaluator *ckks.Evaluator, encoder *ckks.Encoder, params ckks.Parameters, ct *rlwe.Ciphertext, value float64) (*rlwe.Ciphertext, error) {
+	pt := constPlaintext(encoder, params, ct.Level(), value)
+	result, err := evaluator.MulNew(ct, pt)
+	if err != nil {
+		return nil, err
+	}
+	if err := evaluator.Rescale(result, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// oneMinus computes 1 - ct. CKKS has no standalone negation helper already
+// in use elsewhere in this codebase, so it's built from the same
+// multiply-by-constant primitive as constMulNew.
+func oneMinus(evaluator *ckks.Evaluator, encoder *ckks.Encoder, params ckks.Parameters, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	neg, err := constMulNew(evaluator, encoder, params, ct, -1.0)
+	if err != nil {
+		return nil, err
+	}
+	onePt := constPlaintext(encoder, params, neg.Level(), 1.0)
+	result := neg.CopyNew()
+	if err := evaluator.Add(result, onePt, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func onePlus(evaluator *ckks.Evaluator, encoder *ckks.Encoder, params ckks.Parameters, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	onePt := constPlaintext(encoder, params, ct.Level(), 1.0)
+	result := ct.CopyNew()
+	if err := evaluator.Add(result, onePt, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package softmax
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// Softmax homomorphically evaluates a K-class softmax over `logits`, one
+// ciphertext per class: each logit is passed through `exp`, the results are
+// summed, the sum is inverted via NewtonInverse(inv), and every exp'd logit
+// is scaled by that inverse. Every logit must lie within exp's fitting
+// interval and inv.InitialGuess must lower-bound 1/Σexp(zₖ) for the
+// iteration to converge.
+func Softmax(evaluator *ckks.Evaluator, logits []*rlwe.Ciphertext, params ckks.Parameters, exp *ExpApprox, inv InverseConfig) ([]*rlwe.Ciphertext, error) {
+	if len(logits) == 0 {
+		return nil, fmt.Errorf("softmax: Softmax requires at least one class")
+	}
+
+	expCts := make([]*rlwe.Ciphertext, len(logits))
+	for k, ct := range logits {
+		e, err := exp.Evaluate(evaluator, ct, params)
+		if err != nil {
+			return nil, fmt.Errorf("softmax: class %d exp evaluation failed: %v", k, err)
+		}
+		expCts[k] = e
+	}
+
+	sum := expCts[0].CopyNew()
+	for k := 1; k < len(expCts); k++ {
+		if err := evaluator.Add(sum, expCts[k], sum); err != nil {
+			return nil, fmt.Errorf("softmax: summing class %d failed: %v", k, err)
+		}
+	}
+
+	reciprocal, err := NewtonInverse(evaluator, sum, params, inv)
+	if err != nil {
+		return nil, fmt.Errorf("softmax: normalizer inverse failed: %v", err)
+	}
+
+	probs := make([]*rlwe.Ciphertext, len(expCts))
+	for k, e := range expCts {
+		p, err := evaluator.MulRelinNew(e, reciprocal)
+		if err != nil {
+			return nil, fmt.Errorf("softmax: scaling class %d by the normalizer failed: %v", k, err)
+		}
+		if err := evaluator.Rescale(p, p); err != nil {
+			return nil, fmt.Errorf("softmax: rescaling class %d failed: %v", k, err)
+		}
+		probs[k] = p
+	}
+
+	return probs, nil
+}
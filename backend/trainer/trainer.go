@@ -0,0 +1,256 @@
+// Package trainer runs logistic-regression training directly on CKKS
+// ciphertexts, so NoiseTestModel's weights/bias no longer have to be
+// hand-picked plaintext floats. It follows the standard SGD update
+// wᵢ ← wᵢ − η·(σ(wᵀx+b) − y)·xᵢ (and analogously for b), reusing
+// sigmoid.Approximation for σ on the encrypted logit.
+package trainer
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
+)
+
+// Refresher restores a ciphertext's level budget, either via a real
+// bootstrap or (for parameter sets without bootstrapping keys) a
+// decrypt/re-encrypt cycle behind the same interface, so Train doesn't need
+// to know which one it's talking to.
+type Refresher interface {
+	Refresh(ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error)
+}
+
+// Config controls a single Train call.
+type Config struct {
+	LearningRate float64
+	Epochs       int
+	// LevelFloor is the minimum level a ciphertext may have before Train
+	// asks the Refresher to top it back up, mirroring the noise-budget
+	// checks printNoiseSummary reports after scoring.
+	LevelFloor int
+}
+
+// Trainer holds the CKKS components needed to run encrypted SGD.
+type Trainer struct {
+	params    ckks.Parameters
+	encoder   *ckks.Encoder
+	evaluator *ckks.Evaluator
+	cfg       Config
+}
+
+// NewTrainer builds a Trainer bound to params/evaluator/encoder and cfg.
+func NewTrainer(params ckks.Parameters, evaluator *ckks.Evaluator, encoder *ckks.Encoder, cfg Config) *Trainer {
+	return &Trainer{params: params, encoder: encoder, evaluator: evaluator, cfg: cfg}
+}
+
+// Train runs cfg.Epochs mini-batch epochs of encrypted SGD. designMatrix has
+// one ciphertext per feature, each packing one sample per slot (numSamples
+// slots used); labels packs the corresponding y values the same way.
+// initWeights/initBias are the encrypted starting point. refresher may be
+// nil, in which case Train returns an error instead of continuing once a
+// ciphertext's level drops below cfg.LevelFloor.
+func (t *Trainer) Train(sigmoidApprox sigmoid.Approximation, designMatrix []*rlwe.Ciphertext, labels *rlwe.Ciphertext, initWeights []*rlwe.Ciphertext, initBias *rlwe.Ciphertext, numSamples int, refresher Refresher) ([]*rlwe.Ciphertext, *rlwe.Ciphertext, error) {
+	if len(designMatrix) != len(initWeights) {
+		return nil, nil, fmt.Errorf("trainer: design matrix has %d features but %d initial weights given", len(designMatrix), len(initWeights))
+	}
+
+	weights := make([]*rlwe.Ciphertext, len(initWeights))
+	for i, w := range initWeights {
+		weights[i] = w.CopyNew()
+	}
+	bias := initBias.CopyNew()
+
+	for epoch := 0; epoch < t.cfg.Epochs; epoch++ {
+		logit, err := t.weightedSum(designMatrix, weights, bias)
+		if err != nil {
+			return nil, nil, fmt.Errorf("trainer epoch %d: logit failed: %v", epoch, err)
+		}
+
+		pred, err := sigmoidApprox.Evaluate(t.evaluator, logit, t.params)
+		if err != nil {
+			return nil, nil, fmt.Errorf("trainer epoch %d: sigmoid failed: %v", epoch, err)
+		}
+
+		predAligned, labelsAligned, err := t.alignLevels(pred, labels)
+		if err != nil {
+			return nil, nil, fmt.Errorf("trainer epoch %d: level alignment failed: %v", epoch, err)
+		}
+
+		errCt, err := t.evaluator.SubNew(predAligned, labelsAligned)
+		if err != nil {
+			return nil, nil, fmt.Errorf("trainer epoch %d: error term failed: %v", epoch, err)
+		}
+
+		scale := -t.cfg.LearningRate / float64(numSamples)
+
+		for f := range weights {
+			grad, err := t.gradientTerm(errCt, designMatrix[f], scale)
+			if err != nil {
+				return nil, nil, fmt.Errorf("trainer epoch %d: gradient %d failed: %v", epoch, f, err)
+			}
+			updated, err := t.evaluator.AddNew(weights[f], grad)
+			if err != nil {
+				return nil, nil, fmt.Errorf("trainer epoch %d: weight %d update failed: %v", epoch, f, err)
+			}
+			weights[f] = updated
+		}
+
+		biasGrad, err := t.scaleCiphertext(errCt, scale)
+		if err != nil {
+			return nil, nil, fmt.Errorf("trainer epoch %d: bias gradient failed: %v", epoch, err)
+		}
+		biasGradSummed, err := t.reduceSum(biasGrad)
+		if err != nil {
+			return nil, nil, fmt.Errorf("trainer epoch %d: bias reduce failed: %v", epoch, err)
+		}
+		newBias, err := t.evaluator.AddNew(bias, biasGradSummed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("trainer epoch %d: bias update failed: %v", epoch, err)
+		}
+		bias = newBias
+
+		if err := t.refreshIfNeeded(weights, &bias, refresher); err != nil {
+			return nil, nil, fmt.Errorf("trainer epoch %d: refresh failed: %v", epoch, err)
+		}
+	}
+
+	return weights, bias, nil
+}
+
+func (t *Trainer) weightedSum(designMatrix, weights []*rlwe.Ciphertext, bias *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	result, err := t.evaluator.MulRelinNew(designMatrix[0], weights[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := t.evaluator.Rescale(result, result); err != nil {
+		return nil, err
+	}
+
+	for f := 1; f < len(designMatrix); f++ {
+		term, err := t.evaluator.MulRelinNew(designMatrix[f], weights[f])
+		if err != nil {
+			return nil, err
+		}
+		if err := t.evaluator.Rescale(term, term); err != nil {
+			return nil, err
+		}
+		if err := t.evaluator.Add(result, term, result); err != nil {
+			return nil, err
+		}
+	}
+
+	biasAligned, resultAligned, err := t.alignLevels(bias, result)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.evaluator.Add(resultAligned, biasAligned, resultAligned); err != nil {
+		return nil, err
+	}
+	return resultAligned, nil
+}
+
+// gradientTerm computes scale * sum_slots(errCt * feature), broadcast to
+// every slot via reduceSum, so it can be added directly to a weight
+// ciphertext whose value is likewise broadcast across all slots.
+func (t *Trainer) gradientTerm(errCt, feature *rlwe.Ciphertext, scale float64) (*rlwe.Ciphertext, error) {
+	product, err := t.evaluator.MulRelinNew(errCt, feature)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.evaluator.Rescale(product, product); err != nil {
+		return nil, err
+	}
+
+	summed, err := t.reduceSum(product)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.scaleCiphertext(summed, scale)
+}
+
+func (t *Trainer) scaleCiphertext(ct *rlwe.Ciphertext, scalar float64) (*rlwe.Ciphertext, error) {
+	values := make([]complex128, t.params.MaxSlots())
+	for i := range values {
+		values[i] = complex(scalar, 0)
+	}
+	pt := ckks.NewPlaintext(t.params, ct.Level())
+	if err := t.encoder.Encode(values, pt); err != nil {
+		return nil, err
+	}
+	scaled, err := t.evaluator.MulNew(ct, pt)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.evaluator.Rescale(scaled, scaled); err != nil {
+		return nil, err
+	}
+	return scaled, nil
+}
+
+// reduceSum performs a rotate-and-add all-reduce (rotations 1,2,4,...) so
+// every slot ends up holding the total sum across the packed samples —
+// exactly the doubling trick InferenceEngine.InnerProduct uses for features.
+func (t *Trainer) reduceSum(ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	sum := ct.CopyNew()
+	for step := 1; step < t.params.MaxSlots(); step *= 2 {
+		rotated, err := t.evaluator.RotateNew(sum, step)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.evaluator.Add(sum, rotated, sum); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+func (t *Trainer) alignLevels(a, b *rlwe.Ciphertext) (*rlwe.Ciphertext, *rlwe.Ciphertext, error) {
+	aOut, bOut := a.CopyNew(), b.CopyNew()
+	if aOut.Level() == bOut.Level() {
+		return aOut, bOut, nil
+	}
+	if aOut.Level() > bOut.Level() {
+		if err := t.evaluator.DropLevel(aOut, aOut.Level()-bOut.Level()); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if err := t.evaluator.DropLevel(bOut, bOut.Level()-aOut.Level()); err != nil {
+			return nil, nil, err
+		}
+	}
+	return aOut, bOut, nil
+}
+
+// refreshIfNeeded bootstraps any ciphertext whose level has fallen to or
+// below cfg.LevelFloor, matching the "would go negative" budget check
+// printNoiseSummary already reports on.
+func (t *Trainer) refreshIfNeeded(weights []*rlwe.Ciphertext, bias **rlwe.Ciphertext, refresher Refresher) error {
+	needsRefresh := (*bias).Level() <= t.cfg.LevelFloor
+	for _, w := range weights {
+		if w.Level() <= t.cfg.LevelFloor {
+			needsRefresh = true
+		}
+	}
+	if !needsRefresh {
+		return nil
+	}
+	if refresher == nil {
+		return fmt.Errorf("level budget exhausted (floor=%d) and no Refresher configured", t.cfg.LevelFloor)
+	}
+
+	for i, w := range weights {
+		refreshed, err := refresher.Refresh(w)
+		if err != nil {
+			return fmt.Errorf("weight %d refresh failed: %v", i, err)
+		}
+		weights[i] = refreshed
+	}
+	refreshedBias, err := refresher.Refresh(*bias)
+	if err != nil {
+		return fmt.Errorf("bias refresh failed: %v", err)
+	}
+	*bias = refreshedBias
+	return nil
+}
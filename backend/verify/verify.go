@@ -0,0 +1,398 @@
+// Package verify lets an applicant cryptographically check that the scoring
+// service actually applied the model it committed to at startup, rather than
+// a substituted set of weights. InferenceEngine.InferCreditScore otherwise
+// silently trusts the server; this package ties the encrypted output to a
+// published commitment without revealing the weights themselves.
+//
+// Proving knowledge of each commitment's opening (Prove/Verify's
+// ValueResponses/BlindResponses) isn't enough on its own: a server that
+// knows the real (w, b) behind its published commitment could still have
+// evaluated different weights on the ciphertext and produced a transcript
+// that verifies. LinearAnnouncement/LinearResponse closes that gap with a
+// second Schnorr proof, folded into the same Fiat-Shamir challenge, binding
+// the commitments to the actual score: the applicant already knows ctIn's
+// cleartext features (it encrypted them) and, after decrypting ctOut with
+// its own key, the score CKKS computed from them — so it can form
+// D = Σ feature_i•C_i + (bias multiplier)•C_bias, a commitment that opens to
+// exactly that score if and only if the committed weights are the ones the
+// server's homomorphic Mul/Add chain actually used, and prove knowledge of
+// that opening's blinding factor without revealing any individual weight.
+//
+// The linear proof is exact fixed-point arithmetic over this package's
+// curve, not a range proof: it assumes the caller has already quantized
+// CKKS's approximate decoded score (and the cleartext features) onto the
+// same featureScale/weightScale grid floatToScalar/CommitWeight use, the
+// same way DecodeScore clamps CKKS's decoded output elsewhere in this repo.
+// It does not itself model CKKS's approximation noise as an error term, so
+// a caller comparing against a raw decoded float must round consistently
+// before calling Verify or genuine transcripts will fail to verify.
+//
+// This package is not wired into baseline/logn14's server: that server's
+// "did it use the model it claims" problem is instead solved by
+// baseline/logn14/proof, which signs a transcript of the inference steps
+// with an Ed25519 key published at startup. CommitModel/Prover/Verifier
+// remain here as a standalone library for a caller that needs an actual
+// zero-knowledge binding (no published signing key to trust) rather than a
+// signature over a transcript.
+package verify
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// curve is the group Pedersen commitments and the Schnorr-style proof are
+// computed over. P256 is used purely for its standard-library availability;
+// nothing here depends on curve-specific properties beyond the group law.
+var curve = elliptic.P256()
+
+// weightScale is the fixed-point scale CommitWeight/floatToScalar encode a
+// weight or bias at. featureScale is the (coarser) scale a cleartext feature
+// is encoded at when used as a public exponent in the linear-combination
+// proof; combinedScale is the resulting scale of the weighted-sum-plus-bias
+// exponent the linear proof checks against a scaled score.
+const (
+	weightScale   = 1e9
+	featureScale  = 1e6
+	combinedScale = featureScale * weightScale
+)
+
+// Commitment is a hash-commitment to the model weights, bias, and an
+// architecture identifier, published by the service on startup:
+// C = H(w || b || arch_id).
+type Commitment [32]byte
+
+// CommitModel computes C = H(w||b||arch_id).
+func CommitModel(weights []float64, bias float64, archID string) Commitment {
+	h := sha256.New()
+	for _, w := range weights {
+		fmt.Fprintf(h, "%.17g|", w)
+	}
+	fmt.Fprintf(h, "%.17g|%s", bias, archID)
+
+	var c Commitment
+	copy(c[:], h.Sum(nil))
+	return c
+}
+
+// pedersenCommit computes g^value * h^blind on the curve, returning the
+// point coordinates.
+func pedersenCommit(g, h *point, value, blind *big.Int) *point {
+	gx, gy := curve.ScalarMult(g.x, g.y, value.Bytes())
+	hx, hy := curve.ScalarMult(h.x, h.y, blind.Bytes())
+	x, y := curve.Add(gx, gy, hx, hy)
+	return &point{x, y}
+}
+
+type point struct {
+	x, y *big.Int
+}
+
+// generators derives a second, nothing-up-my-sleeve generator h from the
+// curve's base point g by hashing it onto the curve, so no party knows
+// log_g(h).
+func generators() (g, h *point) {
+	gx, gy := curve.Params().Gx, curve.Params().Gy
+	g = &point{gx, gy}
+
+	seed := sha256.Sum256(append(gx.Bytes(), gy.Bytes()...))
+	hx, hy := curve.ScalarBaseMult(seed[:])
+	h = &point{hx, hy}
+	return g, h
+}
+
+// WeightCommitment is a Pedersen commitment to a single model weight (or the
+// bias), plus the blinding factor the prover needs later to prove knowledge
+// of the committed value. The blinding factor never leaves the prover.
+type WeightCommitment struct {
+	Point *point
+	blind *big.Int
+	value *big.Int
+}
+
+// CommitWeight produces a hiding, binding commitment to a weight so the
+// applicant can later verify the server used exactly this value without
+// learning it.
+func CommitWeight(weight float64) (*WeightCommitment, error) {
+	g, h := generators()
+
+	blind, err := rand.Int(rand.Reader, curve.Params().N)
+	if err != nil {
+		return nil, fmt.Errorf("weight commitment failed: %v", err)
+	}
+
+	value := floatToScalar(weight)
+	return &WeightCommitment{
+		Point: pedersenCommit(g, h, value, blind),
+		blind: blind,
+		value: value,
+	}, nil
+}
+
+// floatToScalar maps a weight to a curve scalar with fixed-point scaling,
+// matching the precision CKKS itself encodes at.
+func floatToScalar(f float64) *big.Int {
+	return scaleToInt(f, weightScale)
+}
+
+// scaleToInt maps f to a curve scalar at the given fixed-point scale.
+func scaleToInt(f float64, scale float64) *big.Int {
+	scaled := big.NewFloat(f * scale)
+	i, _ := scaled.Int(nil)
+	return i.Mod(i, curve.Params().N)
+}
+
+// negatePoint returns -p on curve: for short Weierstrass curves, negating a
+// point negates only its y-coordinate, mod the field prime.
+func negatePoint(p *point) *point {
+	y := new(big.Int).Sub(curve.Params().P, p.y)
+	y.Mod(y, curve.Params().P)
+	return &point{p.x, y}
+}
+
+// addPoints returns a+b on curve, or a if b is nil (the identity, used when
+// folding a variable-length list of terms with no separate zero value).
+func addPoints(a, b *point) *point {
+	if b == nil {
+		return a
+	}
+	x, y := curve.Add(a.x, a.y, b.x, b.y)
+	return &point{x, y}
+}
+
+// scalarMultPoint returns k*p on curve.
+func scalarMultPoint(p *point, k *big.Int) *point {
+	x, y := curve.ScalarMult(p.x, p.y, k.Bytes())
+	return &point{x, y}
+}
+
+// linearCombination computes D = Σ featureScalars[i]*weightCommitments[i] +
+// biasMultiplier*biasCommitment, the public commitment a verifier can derive
+// from the published per-weight commitments and its own cleartext features
+// without ever seeing a weight. Because Pedersen commitments are additively
+// homomorphic, D opens to (Σ feature_i*weight_i + biasMultiplier*bias, R) for
+// the matching aggregate blinding factor R — i.e. to the scaled score, if and
+// only if weightCommitments/biasCommitment are what was actually evaluated.
+func linearCombination(weightCommitments []*WeightCommitment, featureScalars []*big.Int, biasCommitment *WeightCommitment, biasExponent *big.Int) *point {
+	var d *point
+	for i, wc := range weightCommitments {
+		d = addPoints(scalarMultPoint(wc.Point, featureScalars[i]), d)
+	}
+	return addPoints(scalarMultPoint(biasCommitment.Point, biasExponent), d)
+}
+
+// featureScalars maps each cleartext feature to the fixed-point exponent
+// linearCombination/Prove/Verify use to weight its commitment.
+func featureScalarsFor(features []float64) []*big.Int {
+	scalars := make([]*big.Int, len(features))
+	for i, f := range features {
+		scalars[i] = scaleToInt(f, featureScale)
+	}
+	return scalars
+}
+
+// biasMultiplier is the constant exponent biasCommitment is raised to in
+// linearCombination: the bias has no associated feature, so it's scaled by
+// featureScale alone (vs. a weight's feature_i*weightScale) to land every
+// term of D at the same combinedScale.
+func biasMultiplier() *big.Int {
+	return big.NewInt(int64(featureScale))
+}
+
+// Proof is a non-interactive (Fiat-Shamir) Chaum-Pedersen/Schnorr-on-Pedersen
+// transcript proving knowledge of the (value, blind) opening behind each of
+// p's WeightCommitments — i.e. of the weights and bias themselves, without
+// revealing them — tied to the specific (ctIn, ctOut) pair via the
+// Fiat-Shamir challenge. A Pedersen commitment C = g^v * h^r hides two
+// secrets, so proving knowledge of it needs a response for each: one for v
+// and one for r. A single response (as if h's exponent were always 0) proves
+// nothing, since the verifier has no way to recover v alone from it.
+//
+// LinearAnnouncement/LinearResponse is a second Schnorr proof, folded into
+// the same challenge, of knowledge of the aggregate blinding factor behind
+// D - g^score (see linearCombination) — the piece that actually ties these
+// weight openings to the claimed score, rather than merely to each other.
+type Proof struct {
+	Challenge      *big.Int
+	ValueResponses []*big.Int // s_v = k_v + challenge*value,  one per committed weight, plus the bias
+	BlindResponses []*big.Int // s_r = k_r + challenge*blind, same order as ValueResponses
+
+	LinearAnnouncement *point   // h^k_R, the nonce commitment for the linear-combination proof
+	LinearResponse     *big.Int // s_R = k_R + challenge*R
+}
+
+// Prover proves that a set of committed weights (and bias) were the ones
+// used to produce ctOut from ctIn.
+type Prover struct {
+	commitments []*WeightCommitment
+	biasCommit  *WeightCommitment
+}
+
+// NewProver bundles the per-weight commitments generated at model load time.
+func NewProver(weightCommits []*WeightCommitment, biasCommit *WeightCommitment) *Prover {
+	return &Prover{commitments: weightCommits, biasCommit: biasCommit}
+}
+
+// Prove builds a Chaum-Pedersen transcript (challenge, responses) proving
+// knowledge of the (value, blind) opening behind every one of p's
+// commitments, plus a Schnorr proof binding those openings to score: the
+// value the server claims its homomorphic evaluation of features against
+// them (producing ctOut from ctIn) actually decodes to. It reads the weight
+// values and blinding factors straight off p.commitments/p.biasCommit —
+// those are exactly the openings CommitWeight generated them from — rather
+// than taking weights/bias as parameters the caller could pass out of sync
+// with what was actually committed. features and score must already be
+// quantized onto this package's fixed-point grid (see the package doc).
+func (p *Prover) Prove(ctInFingerprint, ctOutFingerprint []byte, features []float64, score float64) (*Proof, error) {
+	if len(features) != len(p.commitments) {
+		return nil, fmt.Errorf("prove failed: have %d features, want %d (one per weight commitment)", len(features), len(p.commitments))
+	}
+
+	g, h := generators()
+	n := curve.Params().N
+
+	commits := append(append([]*WeightCommitment{}, p.commitments...), p.biasCommit)
+
+	// One random (value nonce, blind nonce) pair per committed opening.
+	valueNonces := make([]*big.Int, len(commits))
+	blindNonces := make([]*big.Int, len(commits))
+	announcements := make([]*point, len(commits))
+	for i := range commits {
+		valueNonce, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return nil, fmt.Errorf("prove failed: %v", err)
+		}
+		blindNonce, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return nil, fmt.Errorf("prove failed: %v", err)
+		}
+		valueNonces[i] = valueNonce
+		blindNonces[i] = blindNonce
+		announcements[i] = pedersenCommit(g, h, valueNonce, blindNonce)
+	}
+
+	// R is the aggregate blinding factor behind D = Σ feature_i*C_i +
+	// biasMultiplier*C_bias: since each C_i = g^value_i * h^blind_i, D opens
+	// to (Σ feature_i*value_i + biasMultiplier*bias, R) for exactly this R.
+	featureScalars := featureScalarsFor(features)
+	R := new(big.Int)
+	for i, wc := range p.commitments {
+		R.Add(R, new(big.Int).Mul(featureScalars[i], wc.blind))
+	}
+	R.Add(R, new(big.Int).Mul(biasMultiplier(), p.biasCommit.blind))
+	R.Mod(R, n)
+
+	kR, err := rand.Int(rand.Reader, n)
+	if err != nil {
+		return nil, fmt.Errorf("prove failed: %v", err)
+	}
+	linearAnnouncement := scalarMultPoint(h, kR)
+
+	challenge := fiatShamirChallenge(ctInFingerprint, ctOutFingerprint, features, score, append(announcements, linearAnnouncement))
+
+	valueResponses := make([]*big.Int, len(commits))
+	blindResponses := make([]*big.Int, len(commits))
+	for i, c := range commits {
+		vResp := new(big.Int).Mul(challenge, c.value)
+		vResp.Add(vResp, valueNonces[i])
+		vResp.Mod(vResp, n)
+		valueResponses[i] = vResp
+
+		rResp := new(big.Int).Mul(challenge, c.blind)
+		rResp.Add(rResp, blindNonces[i])
+		rResp.Mod(rResp, n)
+		blindResponses[i] = rResp
+	}
+
+	linearResponse := new(big.Int).Mul(challenge, R)
+	linearResponse.Add(linearResponse, kR)
+	linearResponse.Mod(linearResponse, n)
+
+	return &Proof{
+		Challenge:          challenge,
+		ValueResponses:     valueResponses,
+		BlindResponses:     blindResponses,
+		LinearAnnouncement: linearAnnouncement,
+		LinearResponse:     linearResponse,
+	}, nil
+}
+
+// Verifier checks Prove's transcripts against the published Commitment.
+type Verifier struct{}
+
+// NewVerifier constructs a stateless verifier.
+func NewVerifier() *Verifier { return &Verifier{} }
+
+// Verify checks that proof ties ctOut to weightCommitments/biasCommitment
+// (the same commitments published alongside CommitModel's C) — and, via
+// proof's linear sub-proof, that those commitments actually opened to score
+// under features — without learning the underlying weights. features and
+// score must be quantized onto this package's fixed-point grid exactly as
+// Prove's caller did (see the package doc); a mismatched quantization makes
+// a genuine transcript fail to verify the same way a forged one would.
+//
+// For each weight/bias commitment C = g^v * h^r, it recomputes the
+// announcement as g^s_v * h^s_r * C^-challenge — which equals the prover's
+// original g^k_v * h^k_r only if s_v and s_r are genuine responses for v and
+// r under challenge. For the linear sub-proof, it derives
+// D = Σ feature_i*C_i + biasMultiplier*C_bias (see linearCombination) and
+// recomputes h^s_R * (D - g^score)^-challenge, which equals the prover's
+// original h^k_R only if D - g^score is itself h^R for the same R the
+// weight/bias responses are consistent with — i.e. only if score is the
+// correct linear combination of the committed weights and bias. It then
+// checks the Fiat-Shamir challenge over every recomputed announcement
+// (weight/bias announcements, then the linear one) matches proof.Challenge.
+func (v *Verifier) Verify(ctInFingerprint, ctOutFingerprint []byte, weightCommitments []*WeightCommitment, biasCommitment *WeightCommitment, features []float64, score float64, proof *Proof) bool {
+	commits := append(append([]*WeightCommitment{}, weightCommitments...), biasCommitment)
+	if len(proof.ValueResponses) != len(commits) || len(proof.BlindResponses) != len(commits) {
+		return false
+	}
+	if len(features) != len(weightCommitments) {
+		return false
+	}
+	if proof.LinearAnnouncement == nil || proof.LinearResponse == nil {
+		return false
+	}
+
+	g, h := generators()
+	n := curve.Params().N
+
+	negChallenge := new(big.Int).Neg(proof.Challenge)
+	negChallenge.Mod(negChallenge, n)
+
+	reconstructed := make([]*point, len(commits))
+	for i, c := range commits {
+		lhs := pedersenCommit(g, h, proof.ValueResponses[i], proof.BlindResponses[i])
+		reconstructed[i] = addPoints(lhs, scalarMultPoint(c.Point, negChallenge))
+	}
+
+	d := linearCombination(weightCommitments, featureScalarsFor(features), biasCommitment, biasMultiplier())
+	gScore := scalarMultPoint(g, scaleToInt(score, combinedScale))
+	y := addPoints(d, negatePoint(gScore))
+
+	linearLHS := scalarMultPoint(h, proof.LinearResponse)
+	reconstructedLinear := addPoints(linearLHS, scalarMultPoint(y, negChallenge))
+
+	expectedChallenge := fiatShamirChallenge(ctInFingerprint, ctOutFingerprint, features, score, append(reconstructed, reconstructedLinear))
+	return expectedChallenge.Cmp(proof.Challenge) == 0
+}
+
+func fiatShamirChallenge(ctIn, ctOut []byte, features []float64, score float64, announcements []*point) *big.Int {
+	h := sha256.New()
+	h.Write(ctIn)
+	h.Write(ctOut)
+	for _, f := range features {
+		fmt.Fprintf(h, "%.17g|", f)
+	}
+	fmt.Fprintf(h, "%.17g|", score)
+	for _, a := range announcements {
+		h.Write(a.x.Bytes())
+		h.Write(a.y.Bytes())
+	}
+
+	challenge := new(big.Int).SetBytes(h.Sum(nil))
+	return challenge.Mod(challenge, curve.Params().N)
+}
@@ -0,0 +1,134 @@
+package verify
+
+import "testing"
+
+func commitModel(t *testing.T, weights []float64, bias float64) ([]*WeightCommitment, *WeightCommitment) {
+	t.Helper()
+
+	weightCommits := make([]*WeightCommitment, len(weights))
+	for i, w := range weights {
+		wc, err := CommitWeight(w)
+		if err != nil {
+			t.Fatalf("CommitWeight(%v) failed: %v", w, err)
+		}
+		weightCommits[i] = wc
+	}
+
+	biasCommit, err := CommitWeight(bias)
+	if err != nil {
+		t.Fatalf("CommitWeight(bias=%v) failed: %v", bias, err)
+	}
+	return weightCommits, biasCommit
+}
+
+func dotProduct(weights []float64, features []float64, bias float64) float64 {
+	score := bias
+	for i, w := range weights {
+		score += w * features[i]
+	}
+	return score
+}
+
+func TestProveVerifyRoundTrip(t *testing.T) {
+	weights := []float64{0.3, -0.4, 0.25}
+	bias := 650.0
+	features := []float64{0.8, 0.2, 0.6}
+	score := dotProduct(weights, features, bias)
+
+	weightCommits, biasCommit := commitModel(t, weights, bias)
+	prover := NewProver(weightCommits, biasCommit)
+
+	ctIn := []byte("ctIn-fingerprint")
+	ctOut := []byte("ctOut-fingerprint")
+
+	proof, err := prover.Prove(ctIn, ctOut, features, score)
+	if err != nil {
+		t.Fatalf("Prove failed: %v", err)
+	}
+
+	verifier := NewVerifier()
+	if !verifier.Verify(ctIn, ctOut, weightCommits, biasCommit, features, score, proof) {
+		t.Fatal("Verify rejected a genuine proof")
+	}
+}
+
+func TestVerifyRejectsWrongScore(t *testing.T) {
+	weights := []float64{0.3, -0.4, 0.25}
+	bias := 650.0
+	features := []float64{0.8, 0.2, 0.6}
+	score := dotProduct(weights, features, bias)
+
+	weightCommits, biasCommit := commitModel(t, weights, bias)
+	prover := NewProver(weightCommits, biasCommit)
+
+	ctIn := []byte("ctIn-fingerprint")
+	ctOut := []byte("ctOut-fingerprint")
+
+	proof, err := prover.Prove(ctIn, ctOut, features, score)
+	if err != nil {
+		t.Fatalf("Prove failed: %v", err)
+	}
+
+	verifier := NewVerifier()
+	if verifier.Verify(ctIn, ctOut, weightCommits, biasCommit, features, score+1, proof) {
+		t.Fatal("Verify accepted a proof against a tampered score")
+	}
+}
+
+func TestVerifyRejectsWrongCommitments(t *testing.T) {
+	weights := []float64{0.3, -0.4, 0.25}
+	bias := 650.0
+	features := []float64{0.8, 0.2, 0.6}
+	score := dotProduct(weights, features, bias)
+
+	weightCommits, biasCommit := commitModel(t, weights, bias)
+	prover := NewProver(weightCommits, biasCommit)
+
+	ctIn := []byte("ctIn-fingerprint")
+	ctOut := []byte("ctOut-fingerprint")
+
+	proof, err := prover.Prove(ctIn, ctOut, features, score)
+	if err != nil {
+		t.Fatalf("Prove failed: %v", err)
+	}
+
+	// A different model, committed independently, happens to produce the
+	// same score for these features — the linear proof must still reject it,
+	// since the proof binds to these specific commitments, not merely to a
+	// score value a prover could reach some other way.
+	otherWeights := []float64{0.25, -0.4, 0.3}
+	otherBias := 650.05
+	otherCommits, otherBiasCommit := commitModel(t, otherWeights, otherBias)
+
+	verifier := NewVerifier()
+	if verifier.Verify(ctIn, ctOut, otherCommits, otherBiasCommit, features, score, proof) {
+		t.Fatal("Verify accepted a proof against substituted commitments")
+	}
+}
+
+func TestVerifyRejectsFeatureCountMismatch(t *testing.T) {
+	weights := []float64{0.3, -0.4, 0.25}
+	bias := 650.0
+	features := []float64{0.8, 0.2, 0.6}
+	score := dotProduct(weights, features, bias)
+
+	weightCommits, biasCommit := commitModel(t, weights, bias)
+	prover := NewProver(weightCommits, biasCommit)
+
+	ctIn := []byte("ctIn-fingerprint")
+	ctOut := []byte("ctOut-fingerprint")
+
+	if _, err := prover.Prove(ctIn, ctOut, features[:2], score); err == nil {
+		t.Fatal("Prove accepted a features slice shorter than the committed weights")
+	}
+
+	proof, err := prover.Prove(ctIn, ctOut, features, score)
+	if err != nil {
+		t.Fatalf("Prove failed: %v", err)
+	}
+
+	verifier := NewVerifier()
+	if verifier.Verify(ctIn, ctOut, weightCommits, biasCommit, features[:2], score, proof) {
+		t.Fatal("Verify accepted a features slice shorter than the committed weights")
+	}
+}
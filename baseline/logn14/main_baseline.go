@@ -3,21 +3,38 @@ package main
 import (
 	"encoding/base64"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"runtime"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
 	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks/bootstrapping"
+	"github.com/z3rotig4r/ckks_credit/backend/evalkeycache"
+	"github.com/z3rotig4r/ckks_credit/backend/modelregistry"
 	"github.com/z3rotig4r/ckks_credit/backend/sigmoid"
+	"github.com/z3rotig4r/ckks_credit/baseline/logn14/proof"
+	"github.com/z3rotig4r/ckks_credit/baseline/logn14/ratelimit"
+	"golang.org/x/crypto/acme/autocert"
 )
 
 const (
 	// Maximum ciphertext size: 10MB (보안: 악의적 대용량 데이터 차단)
 	MaxCiphertextSize = 10 * 1024 * 1024
+
+	// MaxKeyMaterialSize bounds a single uploaded key field (relinearization
+	// key, Galois key buffer, or bootstrapping key) in POST /api/session/keys.
+	// It's sized well above MaxCiphertextSize because evaluation keys are
+	// legitimately tens of megabytes, but still bounded so a malicious
+	// upload can't exhaust server memory indefinitely.
+	MaxKeyMaterialSize = 128 * 1024 * 1024
 )
 
 var (
@@ -26,20 +43,68 @@ var (
 	encoder   *ckks.Encoder
 	sk        *rlwe.SecretKey
 	rlk       *rlwe.RelinearizationKey
-)
 
-type LogisticRegressionModel struct {
-	Weights []float64
-	Bias    float64
-}
+	// keyCache lets a client upload its RLK/Galois keys once (POST
+	// /api/session/keys) and reuse the evaluator they build across many
+	// /api/inference(-packed) calls by sending back session_id and
+	// keys_fingerprint, instead of re-deserializing tens of megabytes of
+	// key material on every request.
+	keyCache = evalkeycache.NewLRUStore(evalkeycache.DefaultTTL, evalkeycache.DefaultMaxBytes, evalkeycache.DefaultMaxSessions)
+
+	// btpParams and bootstrappingEnabled are set up in init(): if this
+	// scheme's parameters support Lattigo's bootstrapping circuit,
+	// maybeBootstrap can refresh a ciphertext that has run out of levels
+	// instead of feeding the sigmoid stage a silently-corrupted result.
+	btpParams            bootstrapping.Parameters
+	bootstrappingEnabled bool
+
+	// bootstrapThresholdMargin is set from --bootstrap-margin in main(): the
+	// extra levels of headroom maybeBootstrap demands on top of a stage's own
+	// RequiredDepth before it stops considering a ciphertext "low". 0 (the
+	// default) bootstraps only once the circuit would otherwise fall short;
+	// an operator who wants the post-bootstrap budget to cover more than one
+	// more stage ahead can raise it.
+	bootstrapThresholdMargin int
+
+	// modelRegistry replaces the old hard-coded package-level model: it's
+	// built in main() from --models-dir (falling back to defaultModel if
+	// that directory has no entry for --default-model yet) and routes every
+	// inference request to the model version it asked for, or the
+	// configured default if it didn't pin one.
+	modelRegistry *modelregistry.Registry
+
+	// proofSigner signs every inference's proof.Transcript so a client can
+	// detect a tampered response; generated once at startup, same lifetime
+	// as sk/rlk above.
+	proofSigner *proof.Signer
+
+	// modelCommitments caches one proof.ModelCommitment per "id@version" so
+	// repeated requests against the same model reuse its commitment instead
+	// of re-hashing weights and minting a new nonce every time — a
+	// commitment's ID would otherwise change on every request, which would
+	// make GET /api/model-commitment useless as a stable value for clients
+	// to pin against.
+	modelCommitments   = map[string]*proof.ModelCommitment{}
+	modelCommitmentsMu sync.Mutex
+
+	// limiter throttles /api/inference and /api/inference-packed: a
+	// per-client-IP and per-session token bucket plus a global concurrency
+	// semaphore sized to runtime.NumCPU(), built in main().
+	limiter *ratelimit.Limiter
+)
 
-// Production model trained on 307,499 samples from application_train.csv
+// defaultModel is the production model trained on 307,499 samples from
+// application_train.csv, seeded into modelRegistry at startup under id
+// "credit-scorer" so the server has something to route to even before an
+// operator drops any file into --models-dir.
 // User provides 4 inputs: age, loanAmount, income, monthlyPayment
 // Frontend calculates 5 features and sends encrypted to backend
 // Backend features: [age/10, loan_to_income, debt_to_income, credit_amount, income/100000]
 // AUC-ROC: 0.5886, All coefficients CKKS-safe (0.01 ~ 1.0 range)
 // NOTE: EXT_SOURCE_2 제거! 우리가 신용점수를 계산하는 시스템이므로!
-var model = LogisticRegressionModel{
+var defaultModel = modelregistry.Model{
+	ID:      "credit-scorer",
+	Version: "v1",
 	Weights: []float64{
 		-0.2501752295, // age (years / 10)
 		0.0137090654,  // loan_to_income (loanAmount / income)
@@ -47,30 +112,210 @@ var model = LogisticRegressionModel{
 		-0.0426762083, // credit_amount (loanAmount / 100000)
 		0.0062886554,  // income (income / 100000)
 	},
-	Bias: -1.4136778933,
+	Bias:          -1.4136778933,
+	FeatureNames:  []string{"age", "loan_to_income", "debt_to_income", "credit_amount", "income"},
+	SigmoidRange:  [2]float64{-3, -1},
+	SigmoidDegree: 3,
 }
 
 type InferenceRequest struct {
 	EncryptedFeatures  []string `json:"encryptedFeatures"`
 	RelinearizationKey string   `json:"relinearizationKey"` // Base64-encoded RLK from client
+	// SessionID and KeysFingerprint are optional: when both are set and
+	// keyCache has a live entry for SessionID matching KeysFingerprint, the
+	// handler reuses that cached evaluator and skips deserializing
+	// RelinearizationKey entirely (it may be left empty in that case).
+	SessionID       string `json:"session_id,omitempty"`
+	KeysFingerprint string `json:"keys_fingerprint,omitempty"`
+	// ModelID and ModelVersion pin which modelRegistry entry to score
+	// against; left empty, the request routes to the configured default
+	// (or, with some probability, the A/B candidate).
+	ModelID      string `json:"model_id,omitempty"`
+	ModelVersion string `json:"model_version,omitempty"`
+	// BatchSize is how many applicants are packed into slots [0..BatchSize)
+	// of every ciphertext in EncryptedFeatures — slot s of the i-th
+	// ciphertext holds applicant s's value for feature i. Left unset (or 1),
+	// a request scores the single applicant packed into slot 0, same as
+	// before BatchSize existed.
+	BatchSize int `json:"batch_size,omitempty"`
 }
 
 type PackedInferenceRequest struct {
 	EncryptedVector    string `json:"encryptedVector"`    // Single ciphertext with all features
 	RelinearizationKey string `json:"relinearizationKey"` // Base64-encoded RLK from client
 	GaloisKey          string `json:"galoisKey"`          // Base64-encoded Galois key for rotations
+	SessionID          string `json:"session_id,omitempty"`
+	KeysFingerprint    string `json:"keys_fingerprint,omitempty"`
+	ModelID            string `json:"model_id,omitempty"`
+	ModelVersion       string `json:"model_version,omitempty"`
 }
 
 type InferenceResponse struct {
 	EncryptedScore string `json:"encryptedScore"`
 	Timestamp      int64  `json:"timestamp"`
+	// Transcript, Signature and ModelCommitmentID let a client verify this
+	// inference actually ran against the committed model, starting from the
+	// ciphertexts it submitted — see proof.Verify.
+	Transcript        *proof.Transcript `json:"transcript"`
+	Signature         string            `json:"signature"`          // base64-encoded Ed25519 signature over Transcript
+	ModelCommitmentID string            `json:"model_commitment_id"`
+	// BatchSize echoes the request's InferenceRequest.BatchSize (1 if it was
+	// left unset), telling the client how many applicants' scores are packed
+	// into EncryptedScore's leading slots.
+	BatchSize int `json:"batch_size"`
+}
+
+// modelCommitmentFor returns m's cached proof.ModelCommitment, minting one
+// the first time m's id@version is seen.
+func modelCommitmentFor(m *modelregistry.Model) (*proof.ModelCommitment, error) {
+	key := m.ID + "@" + m.Version
+
+	modelCommitmentsMu.Lock()
+	defer modelCommitmentsMu.Unlock()
+
+	if c, ok := modelCommitments[key]; ok {
+		return c, nil
+	}
+
+	c, err := proof.NewModelCommitment(m.ID, m.Version, m.Weights, m.Bias)
+	if err != nil {
+		return nil, err
+	}
+	modelCommitments[key] = c
+	return c, nil
+}
+
+// parseGaloisKeyBuffer decodes the length-prefixed buffer of concatenated
+// Galois keys clients upload: each key is a 4-byte big-endian length prefix
+// followed by that many bytes of rlwe.GaloisKey.MarshalBinary output.
+func parseGaloisKeyBuffer(buf []byte) ([]*rlwe.GaloisKey, error) {
+	var keys []*rlwe.GaloisKey
+	offset := 0
+	for offset < len(buf) {
+		if offset+4 > len(buf) {
+			return nil, fmt.Errorf("invalid galois key buffer format")
+		}
+		keyLen := int(buf[offset])<<24 | int(buf[offset+1])<<16 | int(buf[offset+2])<<8 | int(buf[offset+3])
+		offset += 4
+
+		if offset+keyLen > len(buf) {
+			return nil, fmt.Errorf("invalid galois key data length")
+		}
+		gk := new(rlwe.GaloisKey)
+		if err := gk.UnmarshalBinary(buf[offset : offset+keyLen]); err != nil {
+			return nil, fmt.Errorf("unmarshal galois key: %v", err)
+		}
+		keys = append(keys, gk)
+		offset += keyLen
+	}
+	return keys, nil
+}
+
+// evaluatorForRequest returns the ckks.Evaluator to score a request with. If
+// sessionID and keysFingerprint are both set and keyCache has a live entry
+// matching them, it reuses that cached evaluation key set and rlkB64/
+// galoisKeyB64 may be left empty. Otherwise it deserializes rlkB64
+// (required) and galoisKeyB64 (optional, packedInferenceHandler's combined
+// multi-key buffer; empty for inferenceHandler's single-ciphertext path),
+// and — when sessionID is set — caches the result under sessionID and its
+// own fingerprint for the next request to reuse.
+func evaluatorForRequest(sessionID, keysFingerprint, rlkB64, galoisKeyB64 string) (*ckks.Evaluator, error) {
+	if sessionID != "" && keysFingerprint != "" {
+		if evk, ok := keyCache.Get(sessionID, keysFingerprint); ok {
+			return ckks.NewEvaluator(params, evk), nil
+		}
+	}
+
+	rlkBytes, err := base64.StdEncoding.DecodeString(rlkB64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid relinearization key encoding: %v", err)
+	}
+	clientRLK := new(rlwe.RelinearizationKey)
+	if err := clientRLK.UnmarshalBinary(rlkBytes); err != nil {
+		return nil, fmt.Errorf("invalid relinearization key format: %v", err)
+	}
+	fingerprintInputs := [][]byte{rlkBytes}
+
+	var galKeys []*rlwe.GaloisKey
+	if galoisKeyB64 != "" {
+		gkBytes, err := base64.StdEncoding.DecodeString(galoisKeyB64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid galois key encoding: %v", err)
+		}
+		galKeys, err = parseGaloisKeyBuffer(gkBytes)
+		if err != nil {
+			return nil, err
+		}
+		fingerprintInputs = append(fingerprintInputs, gkBytes)
+	}
+
+	evk := rlwe.NewMemEvaluationKeySet(clientRLK, galKeys...)
+
+	if sessionID != "" {
+		sizeBytes := 0
+		for _, b := range fingerprintInputs {
+			sizeBytes += len(b)
+		}
+		keyCache.Put(sessionID, evalkeycache.Fingerprint(fingerprintInputs...), evk, sizeBytes)
+	}
+
+	return ckks.NewEvaluator(params, evk), nil
+}
+
+// maybeBootstrap refreshes ct via sessionID's uploaded bootstrapping
+// evaluation keys when its remaining level can't cover requiredDepth — the
+// depth the sigmoid stage about to run needs. It returns ct unchanged (and
+// no error) when there's already enough budget, so callers can invoke it
+// unconditionally before every sigmoid evaluation. If the ciphertext is
+// actually short on levels but bootstrapping isn't available for this
+// parameter set, or the session hasn't uploaded bootstrapping keys, it
+// returns an error instead of silently handing the sigmoid stage a
+// ciphertext that will corrupt the result the way the old
+// "⚠️ Noise budget exhausted" log only warned about.
+func maybeBootstrap(sessionID string, ct *rlwe.Ciphertext, requiredDepth int) (*rlwe.Ciphertext, error) {
+	threshold := requiredDepth + bootstrapThresholdMargin
+	if ct.Level() >= threshold {
+		return ct, nil
+	}
+
+	if !bootstrappingEnabled {
+		return nil, fmt.Errorf("ciphertext at level %d needs %d levels for the sigmoid stage, and bootstrapping is unavailable for this parameter set", ct.Level(), threshold)
+	}
+	if sessionID == "" {
+		return nil, fmt.Errorf("ciphertext at level %d needs %d levels for the sigmoid stage; send session_id with uploaded bootstrapping keys to enable bootstrapping", ct.Level(), threshold)
+	}
+
+	btpEvk, ok := keyCache.BootstrapKey(sessionID)
+	if !ok {
+		return nil, fmt.Errorf("ciphertext at level %d needs %d levels for the sigmoid stage, but session %q has not uploaded bootstrapping keys", ct.Level(), threshold, sessionID)
+	}
+
+	btpEval, err := bootstrapping.NewEvaluator(btpParams, btpEvk)
+	if err != nil {
+		return nil, fmt.Errorf("building bootstrapping evaluator: %v", err)
+	}
+
+	levelBefore := ct.Level()
+	log.Printf("♻️  Level=%d below required %d: bootstrapping...", levelBefore, threshold)
+	start := time.Now()
+	refreshed, err := btpEval.Bootstrap(ct)
+	if err != nil {
+		return nil, fmt.Errorf("bootstrap failed: %v", err)
+	}
+	log.Printf("♻️  Bootstrap complete: Level %d -> %d (%.2f ms)",
+		levelBefore, refreshed.Level(), float64(time.Since(start).Microseconds())/1000.0)
+
+	return refreshed, nil
 }
 
 func init() {
 	var err error
 	params, err = ckks.NewParametersFromLiteral(ckks.ParametersLiteral{
-		LogN:            14,                            // BASELINE configuration
-		LogQ:            []int{60, 40, 40, 40, 40, 60}, // SAME 6 levels as optimized for fair comparison
+		LogN: 14, // BASELINE configuration
+		// widened by 3 levels (was {60, 40, 40, 40, 40, 60}) to leave room for
+		// EncryptedSigmoid's degree-7 minimax approximation on top of the
+		// weighted sum
+		LogQ:            []int{60, 40, 40, 40, 40, 40, 40, 40, 60},
 		LogP:            []int{61},
 		LogDefaultScale: 40,
 	})
@@ -81,6 +326,28 @@ func init() {
 	// Only initialize encoder (evaluator created per-request with client's RLK)
 	encoder = ckks.NewEncoder(params)
 
+	// btpParams describes the bootstrapping circuit for these residual
+	// parameters. Building it doesn't require a secret key — only
+	// GenEvaluationKeys (done client-side, never here) does — so this can
+	// run unconditionally at startup even though no client has uploaded
+	// bootstrapping keys yet. This server never holds a client's secret key
+	// (every evaluation key, including bootstrapping keys, arrives via POST
+	// /api/session/keys — see evaluatorForRequest and keyCache), so there is
+	// no server-side "generate a bootstrapping key at startup" step for
+	// --bootstrap to gate the way the parameter set itself already carries
+	// enough modulus headroom to support bootstrapping unconditionally;
+	// --bootstrap and --bootstrap-margin below instead let an operator
+	// disable maybeBootstrap outright, or demand extra level headroom before
+	// it fires, without needing a second parameter literal.
+	btpParams, err = bootstrapping.NewParametersFromLiteral(params, bootstrapping.ParametersLiteral{})
+	if err != nil {
+		log.Printf("⚠️  Bootstrapping unavailable for these parameters: %v", err)
+		bootstrappingEnabled = false
+	} else {
+		bootstrappingEnabled = true
+		log.Printf("♻️  Bootstrapping parameters ready: clients may upload bootstrapping keys via POST /api/session/keys")
+	}
+
 	log.Printf("CKKS Parameters: LogN=%d, MaxLevel=%d, MaxSlots=%d\n",
 		params.LogN(), params.MaxLevel(), params.MaxSlots())
 	log.Printf("✅ Backend ready to receive client's relinearization key\n")
@@ -101,11 +368,44 @@ func enableCORS(next http.Handler) http.Handler {
 	})
 }
 
+// checkRateLimit applies limiter's per-IP and (if sessionID is non-empty)
+// per-session token buckets to r, writing a 429 with a Retry-After hint and
+// returning false if either is exhausted. Callers must bail out immediately
+// when it returns false.
+func checkRateLimit(w http.ResponseWriter, r *http.Request, sessionID string) bool {
+	ip := limiter.ClientIP(r)
+	if retryAfter, ok := limiter.Allow(ip); !ok {
+		w.Header().Set("Retry-After", fmt.Sprintf("%.0f", retryAfter.Seconds()))
+		http.Error(w, "rate limit exceeded, retry later", http.StatusTooManyRequests)
+		return false
+	}
+	if retryAfter, ok := limiter.AllowSession(sessionID); !ok {
+		w.Header().Set("Retry-After", fmt.Sprintf("%.0f", retryAfter.Seconds()))
+		http.Error(w, "session rate limit exceeded, retry later", http.StatusTooManyRequests)
+		return false
+	}
+	return true
+}
+
+// acquireInferenceSlot claims one of limiter's GlobalConcurrency
+// concurrent-inference slots, writing a 503 with Retry-After and returning
+// false if the server is already running that many. Callers must invoke
+// the returned release func exactly once (it's a no-op on failure).
+func acquireInferenceSlot(w http.ResponseWriter) (release func(), ok bool) {
+	if !limiter.TryAcquire() {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "server at maximum concurrent inference capacity, retry shortly", http.StatusServiceUnavailable)
+		return func() {}, false
+	}
+	return limiter.Release, true
+}
+
 func healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":    "healthy",
-		"timestamp": time.Now().Unix(),
+		"status":      "healthy",
+		"timestamp":   time.Now().Unix(),
+		"rate_limits": limiter.Status(),
 	})
 }
 
@@ -119,39 +419,46 @@ func inferenceHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(req.EncryptedFeatures) != 5 {
-		log.Printf("❌ ERROR: Invalid feature count: expected 5, got %d", len(req.EncryptedFeatures))
-		http.Error(w, "Expected 5 encrypted features", http.StatusBadRequest)
+	if !checkRateLimit(w, r, req.SessionID) {
+		return
+	}
+	release, ok := acquireInferenceSlot(w)
+	if !ok {
 		return
 	}
+	defer release()
 
-	if req.RelinearizationKey == "" {
-		log.Printf("❌ ERROR: Missing relinearization key")
-		http.Error(w, "Relinearization key required", http.StatusBadRequest)
+	selectedModel, isCandidate, err := modelRegistry.Route(req.ModelID, req.ModelVersion)
+	if err != nil {
+		log.Printf("❌ ERROR: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	log.Printf("📨 Received inference request with %d encrypted features + RLK", len(req.EncryptedFeatures))
+	if len(req.EncryptedFeatures) != len(selectedModel.Weights) {
+		log.Printf("❌ ERROR: Invalid feature count: expected %d, got %d", len(selectedModel.Weights), len(req.EncryptedFeatures))
+		http.Error(w, fmt.Sprintf("Expected %d encrypted features", len(selectedModel.Weights)), http.StatusBadRequest)
+		return
+	}
 
-	// Deserialize RLK
-	rlkBytes, err := base64.StdEncoding.DecodeString(req.RelinearizationKey)
-	if err != nil {
-		log.Printf("❌ ERROR: Failed to decode RLK: %v", err)
-		http.Error(w, fmt.Sprintf("Invalid RLK: %v", err), http.StatusBadRequest)
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	if maxBatch := params.MaxSlots() / 2; batchSize > maxBatch {
+		log.Printf("❌ ERROR: batch_size %d exceeds maximum %d applicants per ciphertext", batchSize, maxBatch)
+		http.Error(w, fmt.Sprintf("batch_size exceeds maximum of %d applicants per ciphertext", maxBatch), http.StatusBadRequest)
 		return
 	}
 
-	rlk := new(rlwe.RelinearizationKey)
-	if err := rlk.UnmarshalBinary(rlkBytes); err != nil {
-		log.Printf("❌ ERROR: Failed to unmarshal RLK: %v", err)
-		http.Error(w, fmt.Sprintf("Invalid RLK data: %v", err), http.StatusBadRequest)
+	evaluator, err := evaluatorForRequest(req.SessionID, req.KeysFingerprint, req.RelinearizationKey, "")
+	if err != nil {
+		log.Printf("❌ ERROR: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	// Create evaluator with client's RLK
-	evk := rlwe.NewMemEvaluationKeySet(rlk)
-	evaluator := ckks.NewEvaluator(params, evk)
-	log.Printf("✅ Created evaluator with client's relinearization key")
+	log.Printf("📨 Received inference request with %d encrypted features", len(req.EncryptedFeatures))
 
 	startDeserialization := time.Now()
 	encryptedFeatures := make([]*rlwe.Ciphertext, len(req.EncryptedFeatures))
@@ -204,7 +511,7 @@ func inferenceHandler(w http.ResponseWriter, r *http.Request) {
 		float64(deserializationTime.Microseconds())/1000.0, totalBytes)
 
 	startInference := time.Now()
-	result, err := performInference(evaluator, encryptedFeatures)
+	result, transcript, err := performInference(evaluator, encryptedFeatures, req.SessionID, selectedModel, isCandidate, batchSize)
 	if err != nil {
 		log.Printf("❌ ERROR: Inference failed: %v", err)
 		http.Error(w, fmt.Sprintf("Inference failed: %v", err), http.StatusInternalServerError)
@@ -226,9 +533,20 @@ func inferenceHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("⏱️  Serialization: %.2f ms (%d bytes)",
 		float64(serializationTime.Microseconds())/1000.0, len(resultBytes))
 
+	signature, err := proofSigner.Sign(transcript)
+	if err != nil {
+		log.Printf("❌ ERROR: Failed to sign transcript: %v", err)
+		http.Error(w, "Failed to sign inference transcript", http.StatusInternalServerError)
+		return
+	}
+
 	response := InferenceResponse{
-		EncryptedScore: resultB64,
-		Timestamp:      time.Now().Unix(),
+		EncryptedScore:    resultB64,
+		Timestamp:         time.Now().Unix(),
+		Transcript:        transcript,
+		Signature:         base64.StdEncoding.EncodeToString(signature),
+		ModelCommitmentID: transcript.ModelCommitmentID,
+		BatchSize:         batchSize,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -245,7 +563,27 @@ func inferenceHandler(w http.ResponseWriter, r *http.Request) {
 		float64(serializationTime.Microseconds())/1000.0)
 }
 
-func performInference(evaluator *ckks.Evaluator, features []*rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+// performInference scores features against m, where every ciphertext in
+// features packs batchSize applicants' values into slots [0..batchSize) —
+// SIMD across applicants rather than the single value in slot 0 that
+// batchSize==1 uses. The weighted-sum and bias stages broadcast each weight
+// into every one of those slots instead of slot 0 alone, so the sigmoid
+// stage (already slot-parallel — every Approximation encodes its
+// coefficients across params.MaxSlots()) activates all batchSize applicants
+// in the same pass EncryptedScore costs for one. Decoding result's leading
+// batchSize slots after decryption recovers one score per applicant.
+func performInference(evaluator *ckks.Evaluator, features []*rlwe.Ciphertext, sessionID string, m *modelregistry.Model, isCandidate bool, batchSize int) (*rlwe.Ciphertext, *proof.Transcript, error) {
+	commitment, err := modelCommitmentFor(m)
+	if err != nil {
+		return nil, nil, fmt.Errorf("model commitment: %v", err)
+	}
+	transcript := proof.NewTranscript(commitment.ID)
+	for i, ct := range features {
+		if err := transcript.RecordInput(i, ct); err != nil {
+			return nil, nil, fmt.Errorf("recording transcript input %d: %v", i, err)
+		}
+	}
+
 	startAlign := time.Now()
 
 	// 레벨 맞추기: 모든 암호문을 최소 레벨로 통일
@@ -273,35 +611,42 @@ func performInference(evaluator *ckks.Evaluator, features []*rlwe.Ciphertext) (*
 	// Client encrypts with LogSlots=12, so we must match that
 	actualSlots := params.MaxSlots() / 2 // 4096 for LogN=13
 	values := make([]complex128, actualSlots)
-	values[0] = complex(model.Weights[0], 0)
+	for s := 0; s < batchSize; s++ {
+		values[s] = complex(m.Weights[0], 0)
+	}
 	weightPt := ckks.NewPlaintext(params, features[0].Level())
 	encoder.Encode(values, weightPt)
 
 	result, err := evaluator.MulNew(features[0], weightPt)
 	if err != nil {
-		return nil, fmt.Errorf("multiplication failed: %v", err)
+		return nil, nil, fmt.Errorf("multiplication failed: %v", err)
 	}
 	// ✅ Rescaling 필수: 스케일 정규화
 	if err := evaluator.Rescale(result, result); err != nil {
-		return nil, fmt.Errorf("rescaling failed: %v", err)
+		return nil, nil, fmt.Errorf("rescaling failed: %v", err)
 	}
 	log.Printf("✅ First weight mul + rescale: Level=%d", result.Level())
+	if err := transcript.Record("weight_mul_rescale_0", result); err != nil {
+		return nil, nil, fmt.Errorf("recording transcript: %v", err)
+	}
 
 	for i := 1; i < len(features); i++ {
 		for j := range values {
 			values[j] = 0
 		}
-		values[0] = complex(model.Weights[i], 0)
+		for s := 0; s < batchSize; s++ {
+			values[s] = complex(m.Weights[i], 0)
+		}
 		weightPt := ckks.NewPlaintext(params, features[i].Level())
 		encoder.Encode(values, weightPt)
 
 		weightedFeature, err := evaluator.MulNew(features[i], weightPt)
 		if err != nil {
-			return nil, fmt.Errorf("multiplication failed at feature %d: %v", i, err)
+			return nil, nil, fmt.Errorf("multiplication failed at feature %d: %v", i, err)
 		}
 		// ✅ Rescaling 필수
 		if err := evaluator.Rescale(weightedFeature, weightedFeature); err != nil {
-			return nil, fmt.Errorf("rescaling failed at feature %d: %v", i, err)
+			return nil, nil, fmt.Errorf("rescaling failed at feature %d: %v", i, err)
 		}
 
 		// 덧셈 전 레벨 맞추기
@@ -314,7 +659,10 @@ func performInference(evaluator *ckks.Evaluator, features []*rlwe.Ciphertext) (*
 		}
 
 		if err := evaluator.Add(result, weightedFeature, result); err != nil {
-			return nil, fmt.Errorf("addition failed at feature %d: %v", i, err)
+			return nil, nil, fmt.Errorf("addition failed at feature %d: %v", i, err)
+		}
+		if err := transcript.Record(fmt.Sprintf("weighted_sum_%d", i), result); err != nil {
+			return nil, nil, fmt.Errorf("recording transcript: %v", err)
 		}
 	}
 
@@ -327,33 +675,50 @@ func performInference(evaluator *ckks.Evaluator, features []*rlwe.Ciphertext) (*
 	// Scale bias value to match the post-rescale scale
 	// result.Scale after rescale is DefaultScale / Q[dropped_level]
 	scaleFactor := float64(result.Scale.Uint64()) / float64(params.DefaultScale().Uint64())
-	values[0] = complex(model.Bias*scaleFactor, 0)
+	for s := 0; s < batchSize; s++ {
+		values[s] = complex(m.Bias*scaleFactor, 0)
+	}
 
 	biasPt := ckks.NewPlaintext(params, result.Level())
 	if err := encoder.Encode(values, biasPt); err != nil {
-		return nil, fmt.Errorf("bias encoding failed: %v", err)
+		return nil, nil, fmt.Errorf("bias encoding failed: %v", err)
 	}
 
 	if err := evaluator.Add(result, biasPt, result); err != nil {
-		return nil, fmt.Errorf("bias addition failed: %v", err)
+		return nil, nil, fmt.Errorf("bias addition failed: %v", err)
+	}
+	if err := transcript.Record("bias_add", result); err != nil {
+		return nil, nil, fmt.Errorf("recording transcript: %v", err)
 	}
 
 	weightedSumTime := time.Since(startWeightedSum)
 	log.Printf("⏱️  Weighted sum computation: %.2f ms", float64(weightedSumTime.Microseconds())/1000.0)
 
 	startSigmoid := time.Now()
-	log.Printf("🔐 Applying sigmoid approximation (CreditScoring-3)...")
+	log.Printf("🔐 Applying sigmoid approximation (CreditScoring-%d) for model %s@%s...", m.SigmoidDegree, m.ID, m.Version)
 
 	// Log pre-sigmoid noise budget
 	logitLevel := result.Level()
 	log.Printf("📉 Noise Budget Before Sigmoid: Level=%d/%d (%.1f%% remaining)",
 		logitLevel, params.MaxLevel(), float64(logitLevel)/float64(params.MaxLevel())*100.0)
 
-	// Use optimized CreditScoring sigmoid for [-3, -1] range (0.3% error)
-	sigmoidApprox := sigmoid.NewCreditScoringApprox(3)
+	// Use the model's configured CreditScoring degree for its [-3, -1]-range logit
+	sigmoidApprox := sigmoid.NewCreditScoringApprox(m.SigmoidDegree)
+
+	result, err = maybeBootstrap(sessionID, result, sigmoidApprox.RequiredDepth())
+	if err != nil {
+		return nil, nil, fmt.Errorf("pre-sigmoid bootstrap: %v", err)
+	}
+	if err := transcript.Record("pre_sigmoid_bootstrap", result); err != nil {
+		return nil, nil, fmt.Errorf("recording transcript: %v", err)
+	}
+
 	score, err := sigmoidApprox.Evaluate(evaluator, result, params)
 	if err != nil {
-		return nil, fmt.Errorf("sigmoid evaluation failed: %v", err)
+		return nil, nil, fmt.Errorf("sigmoid evaluation failed: %v", err)
+	}
+	if err := transcript.Record("sigmoid", score); err != nil {
+		return nil, nil, fmt.Errorf("recording transcript: %v", err)
 	}
 
 	// Log post-sigmoid noise budget
@@ -376,18 +741,27 @@ func performInference(evaluator *ckks.Evaluator, features []*rlwe.Ciphertext) (*
 	sigmoidTime := time.Since(startSigmoid)
 	log.Printf("⏱️  Sigmoid approximation: %.2f ms", float64(sigmoidTime.Microseconds())/1000.0)
 
-	return score, nil
+	modelRegistry.RecordInference(m, time.Since(startAlign), levelsConsumed, isCandidate)
+
+	return score, transcript, nil
 }
 
 // performPackedInference: 하나의 암호문에 packed된 여러 피처로 추론 수행
 // Hadamard product (element-wise multiplication) + Sum 방식 사용
-func performPackedInference(evaluator *ckks.Evaluator, packedCt *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+func performPackedInference(evaluator *ckks.Evaluator, packedCt *rlwe.Ciphertext, sessionID string, m *modelregistry.Model, isCandidate bool) (*rlwe.Ciphertext, error) {
+	// The rotate-and-sum below is hard-coded to 5 packed feature slots; a
+	// model with more weights would need more rotations than this function
+	// issues and silently score against a truncated dot product.
+	if len(m.Weights) > 5 {
+		return nil, fmt.Errorf("model %s@%s has %d weights, but packed inference only supports up to 5", m.ID, m.Version, len(m.Weights))
+	}
+
 	startWeightedSum := time.Now()
 
 	// 1. weights를 벡터로 인코딩 (첫 N개 슬롯에 배치)
 	values := make([]complex128, params.MaxSlots())
-	for i := 0; i < len(model.Weights); i++ {
-		values[i] = complex(model.Weights[i], 0)
+	for i := 0; i < len(m.Weights); i++ {
+		values[i] = complex(m.Weights[i], 0)
 	}
 	weightPt := ckks.NewPlaintext(params, packedCt.Level())
 	encoder.Encode(values, weightPt)
@@ -448,7 +822,7 @@ func performPackedInference(evaluator *ckks.Evaluator, packedCt *rlwe.Ciphertext
 
 	// 4. Add bias
 	biasValues := make([]complex128, params.MaxSlots())
-	biasValues[0] = complex(model.Bias, 0)
+	biasValues[0] = complex(m.Bias, 0)
 	biasPt := ckks.NewPlaintext(params, result.Level())
 	encoder.Encode(biasValues, biasPt)
 	evaluator.Add(result, biasPt, result)
@@ -458,13 +832,19 @@ func performPackedInference(evaluator *ckks.Evaluator, packedCt *rlwe.Ciphertext
 
 	// 5. Apply sigmoid
 	startSigmoid := time.Now()
-	log.Printf("🔐 Applying sigmoid approximation (CreditScoring-3)...")
+	log.Printf("🔐 Applying sigmoid approximation (CreditScoring-%d) for model %s@%s...", m.SigmoidDegree, m.ID, m.Version)
 
 	logitLevel := result.Level()
 	log.Printf("📉 Noise Budget Before Sigmoid: Level=%d/%d (%.1f%% remaining)",
 		logitLevel, params.MaxLevel(), float64(logitLevel)/float64(params.MaxLevel())*100.0)
 
-	sigmoidApprox := sigmoid.NewCreditScoringApprox(3)
+	sigmoidApprox := sigmoid.NewCreditScoringApprox(m.SigmoidDegree)
+
+	result, err = maybeBootstrap(sessionID, result, sigmoidApprox.RequiredDepth())
+	if err != nil {
+		return nil, fmt.Errorf("pre-sigmoid bootstrap: %v", err)
+	}
+
 	score, err := sigmoidApprox.Evaluate(evaluator, result, params)
 	if err != nil {
 		return nil, fmt.Errorf("sigmoid evaluation failed: %v", err)
@@ -484,6 +864,8 @@ func performPackedInference(evaluator *ckks.Evaluator, packedCt *rlwe.Ciphertext
 	sigmoidTime := time.Since(startSigmoid)
 	log.Printf("⏱️  Sigmoid approximation: %.2f ms", float64(sigmoidTime.Microseconds())/1000.0)
 
+	modelRegistry.RecordInference(m, time.Since(startWeightedSum), levelsConsumed, isCandidate)
+
 	return score, nil
 }
 
@@ -499,63 +881,28 @@ func packedInferenceHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Deserialize RLK
-	rlkBytes, err := base64.StdEncoding.DecodeString(req.RelinearizationKey)
-	if err != nil {
-		log.Printf("❌ ERROR: Failed to decode RLK: %v", err)
-		http.Error(w, "Invalid relinearization key encoding", http.StatusBadRequest)
+	if !checkRateLimit(w, r, req.SessionID) {
 		return
 	}
-
-	rlk := new(rlwe.RelinearizationKey)
-	if err := rlk.UnmarshalBinary(rlkBytes); err != nil {
-		log.Printf("❌ ERROR: Failed to unmarshal RLK: %v", err)
-		http.Error(w, "Invalid relinearization key format", http.StatusBadRequest)
+	release, ok := acquireInferenceSlot(w)
+	if !ok {
 		return
 	}
-	log.Printf("✅ Received RLK: %d bytes", len(rlkBytes))
+	defer release()
 
-	// Deserialize Galois keys for rotations
-	gkBytes, err := base64.StdEncoding.DecodeString(req.GaloisKey)
+	selectedModel, isCandidate, err := modelRegistry.Route(req.ModelID, req.ModelVersion)
 	if err != nil {
-		log.Printf("❌ ERROR: Failed to decode Galois key: %v", err)
-		http.Error(w, "Invalid Galois key encoding", http.StatusBadRequest)
+		log.Printf("❌ ERROR: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	// Deserialize multiple Galois keys from combined buffer
-	var galKeys []*rlwe.GaloisKey
-	offset := 0
-	for offset < len(gkBytes) {
-		// Read length prefix (4 bytes)
-		if offset+4 > len(gkBytes) {
-			log.Printf("❌ ERROR: Invalid Galois key buffer format")
-			http.Error(w, "Invalid Galois key format", http.StatusBadRequest)
-			return
-		}
-		keyLen := int(gkBytes[offset])<<24 | int(gkBytes[offset+1])<<16 | int(gkBytes[offset+2])<<8 | int(gkBytes[offset+3])
-		offset += 4
-
-		// Read key data
-		if offset+keyLen > len(gkBytes) {
-			log.Printf("❌ ERROR: Invalid Galois key data length")
-			http.Error(w, "Invalid Galois key format", http.StatusBadRequest)
-			return
-		}
-		gk := new(rlwe.GaloisKey)
-		if err := gk.UnmarshalBinary(gkBytes[offset : offset+keyLen]); err != nil {
-			log.Printf("❌ ERROR: Failed to unmarshal Galois key: %v", err)
-			http.Error(w, "Invalid Galois key format", http.StatusBadRequest)
-			return
-		}
-		galKeys = append(galKeys, gk)
-		offset += keyLen
+	evaluator, err := evaluatorForRequest(req.SessionID, req.KeysFingerprint, req.RelinearizationKey, req.GaloisKey)
+	if err != nil {
+		log.Printf("❌ ERROR: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	log.Printf("✅ Received %d Galois keys: %d bytes total", len(galKeys), len(gkBytes))
-
-	// Create evaluator with client's RLK and Galois keys
-	evk := rlwe.NewMemEvaluationKeySet(rlk, galKeys...)
-	evaluator := ckks.NewEvaluator(params, evk)
 
 	// Deserialize packed ciphertext
 	startDeserialization := time.Now()
@@ -592,7 +939,7 @@ func packedInferenceHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Perform inference
 	startInference := time.Now()
-	result, err := performPackedInference(evaluator, packedCt)
+	result, err := performPackedInference(evaluator, packedCt, req.SessionID, selectedModel, isCandidate)
 	if err != nil {
 		log.Printf("❌ ERROR: Packed inference failed: %v", err)
 		http.Error(w, fmt.Sprintf("Packed inference failed: %v", err), http.StatusInternalServerError)
@@ -634,38 +981,391 @@ func packedInferenceHandler(w http.ResponseWriter, r *http.Request) {
 		float64(serializationTime.Microseconds())/1000.0)
 }
 
+// SessionKeysRequest is POST /api/session/keys' body: a client's RLK and
+// (for packed inference) Galois keys, uploaded once so inferenceHandler and
+// packedInferenceHandler can skip deserializing them on every subsequent
+// call. The response carries the keys_fingerprint the client must echo back
+// as InferenceRequest/PackedInferenceRequest.KeysFingerprint to claim the
+// cached evaluator.
+//
+// BootstrappingKey is optional and independent of the fingerprint dance
+// above: a client only needs to send it once (base64-encoded
+// bootstrapping.EvaluationKeySet.MarshalBinary output, generated from the
+// same secret key as RelinearizationKey) for maybeBootstrap to be able to
+// refresh that session's ciphertexts for the rest of its TTL.
+type SessionKeysRequest struct {
+	SessionID          string `json:"session_id"`
+	RelinearizationKey string `json:"relinearizationKey"`
+	GaloisKey          string `json:"galoisKey,omitempty"`
+	BootstrappingKey   string `json:"bootstrappingKey,omitempty"`
+}
+
+type SessionKeysResponse struct {
+	SessionID          string `json:"session_id"`
+	KeysFingerprint    string `json:"keys_fingerprint"`
+	BootstrappingReady bool   `json:"bootstrapping_ready"`
+}
+
+// sessionKeysHandler handles POST /api/session/keys — this server's
+// session-scoped evaluation key upload endpoint: a client posts its
+// relinearization key (and optionally Galois/bootstrapping keys) once under
+// a session_id it picks, and every inferenceHandler/packedInferenceHandler
+// call that echoes the same session_id and keys_fingerprint builds its
+// per-request ckks.Evaluator from the cached rlwe.MemEvaluationKeySet
+// instead of re-deserializing the keys (see evaluatorForRequest). It
+// deserializes and caches req's keys exactly the way evaluatorForRequest
+// would on a cache miss, so the very next inference request under the same
+// session_id is a cache hit. If req.BootstrappingKey is set, it also
+// attaches the deserialized bootstrapping evaluation key set to the session
+// so maybeBootstrap can find it later. Each key field is capped at
+// MaxKeyMaterialSize, and keyCache itself caps total cached sessions at
+// evalkeycache.DefaultMaxSessions independently of its byte cap.
+func sessionKeysHandler(w http.ResponseWriter, r *http.Request) {
+	var req SessionKeysRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.SessionID == "" {
+		http.Error(w, "session_id is required", http.StatusBadRequest)
+		return
+	}
+
+	for name, b64 := range map[string]string{
+		"relinearizationKey": req.RelinearizationKey,
+		"galoisKey":          req.GaloisKey,
+		"bootstrappingKey":   req.BootstrappingKey,
+	} {
+		if len(b64) > MaxKeyMaterialSize {
+			http.Error(w, fmt.Sprintf("%s exceeds maximum size", name), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if _, err := evaluatorForRequest(req.SessionID, "", req.RelinearizationKey, req.GaloisKey); err != nil {
+		log.Printf("❌ ERROR: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bootstrappingReady := false
+	if req.BootstrappingKey != "" {
+		if !bootstrappingEnabled {
+			http.Error(w, "bootstrapping is not available for this server's parameter set", http.StatusBadRequest)
+			return
+		}
+		btpKeyBytes, err := base64.StdEncoding.DecodeString(req.BootstrappingKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid bootstrapping key encoding: %v", err), http.StatusBadRequest)
+			return
+		}
+		btpEvk := new(bootstrapping.EvaluationKeySet)
+		if err := btpEvk.UnmarshalBinary(btpKeyBytes); err != nil {
+			http.Error(w, fmt.Sprintf("invalid bootstrapping key format: %v", err), http.StatusBadRequest)
+			return
+		}
+		keyCache.PutBootstrapKey(req.SessionID, btpEvk, len(btpKeyBytes))
+		bootstrappingReady = true
+		log.Printf("♻️  Session %s uploaded bootstrapping keys (%d bytes)", req.SessionID, len(btpKeyBytes))
+	}
+
+	info, _ := keyCache.Stat(req.SessionID)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SessionKeysResponse{
+		SessionID:          req.SessionID,
+		KeysFingerprint:    info.Fingerprint,
+		BootstrappingReady: bootstrappingReady,
+	})
+}
+
+// sessionInfoHandler handles GET /api/session/{id} and DELETE
+// /api/session/{id}: the former reports the cached entry's fingerprint,
+// size, and expiry, the latter evicts it early (e.g. the client is done
+// with that session and wants its key material gone before the TTL).
+func sessionInfoHandler(w http.ResponseWriter, r *http.Request) {
+	sessionID := mux.Vars(r)["id"]
+
+	if r.Method == http.MethodDelete {
+		keyCache.Evict(sessionID)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	info, ok := keyCache.Stat(sessionID)
+	if !ok {
+		http.Error(w, "unknown or expired session", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"session_id":       sessionID,
+		"keys_fingerprint": info.Fingerprint,
+		"size_bytes":       info.SizeBytes,
+		"expires_at":       info.ExpiresAt,
+	})
+}
+
+// sessionStatsHandler handles GET /api/session/stats: keyCache's cumulative
+// hit-rate and bytes-saved counters, for monitoring whether the cache is
+// actually paying for itself.
+func sessionStatsHandler(w http.ResponseWriter, r *http.Request) {
+	stats := keyCache.Stats()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"hits":        stats.Hits,
+		"misses":      stats.Misses,
+		"hit_rate":    stats.HitRate(),
+		"bytes_saved": stats.BytesSaved,
+	})
+}
+
+// ServerInfoResponse reports the CKKS parameters this server scores with
+// and whether it supports bootstrapping, so a client can auto-configure its
+// encoder/keygen and decide whether it needs to generate and upload
+// bootstrapping keys via POST /api/session/keys before it can rely on
+// maybeBootstrap for deeper sigmoid approximants.
+type ServerInfoResponse struct {
+	LogN                 int   `json:"logN"`
+	LogQ                 []int `json:"logQ"`
+	LogP                 []int `json:"logP"`
+	LogDefaultScale      int   `json:"logDefaultScale"`
+	MaxLevel             int   `json:"maxLevel"`
+	MaxSlots             int   `json:"maxSlots"`
+	BootstrappingEnabled bool  `json:"bootstrappingEnabled"`
+}
+
+// serverInfoHandler handles GET /api/server-info.
+func serverInfoHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ServerInfoResponse{
+		LogN:                 params.LogN(),
+		LogQ:                 params.LogQ(),
+		LogP:                 params.LogP(),
+		LogDefaultScale:      params.LogDefaultScale(),
+		MaxLevel:             params.MaxLevel(),
+		MaxSlots:             params.MaxSlots(),
+		BootstrappingEnabled: bootstrappingEnabled,
+	})
+}
+
+// modelsHandler handles GET /api/models: every model version modelRegistry
+// currently has loaded, without weights (the registry only returns those
+// from GetMeta, and only if --allow-weights-export is set).
+func modelsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"models": modelRegistry.List(),
+	})
+}
+
+// modelHandler handles GET /api/models/{id}?version=&weights=1: one model's
+// metadata (plus weights/bias if both the server and this request ask for
+// them) and its cumulative serving stats, if any requests have hit it yet.
+func modelHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	version := r.URL.Query().Get("version")
+	includeWeights := r.URL.Query().Get("weights") == "1"
+
+	meta, weights, bias, ok := modelRegistry.GetMeta(id, version, includeWeights)
+	if !ok {
+		http.Error(w, "unknown model", http.StatusNotFound)
+		return
+	}
+
+	resp := map[string]interface{}{"model": meta}
+	if weights != nil {
+		resp["weights"] = weights
+		resp["bias"] = bias
+	}
+	if stats, ok := modelRegistry.Stat(meta.ID, meta.Version); ok {
+		resp["stats"] = stats
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// modelCommitmentResponse is GET /api/model-commitment's body: the
+// committed model's public binding plus the Ed25519 public key clients must
+// verify every inference's transcript signature against (see proof.Verify).
+type modelCommitmentResponse struct {
+	Commitment *proof.ModelCommitment `json:"commitment"`
+	PublicKey  string                 `json:"public_key"` // base64-encoded Ed25519 public key
+}
+
+// modelCommitmentHandler handles GET /api/model-commitment?id=&version=,
+// routing the same way inferenceHandler does so a client can fetch the
+// commitment for whichever model its inference requests will actually hit.
+func modelCommitmentHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	version := r.URL.Query().Get("version")
+
+	m, _, err := modelRegistry.Route(id, version)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	commitment, err := modelCommitmentFor(m)
+	if err != nil {
+		log.Printf("❌ ERROR: %v", err)
+		http.Error(w, "Failed to build model commitment", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(modelCommitmentResponse{
+		Commitment: commitment,
+		PublicKey:  base64.StdEncoding.EncodeToString(proofSigner.PublicKey()),
+	})
+}
+
 func main() {
+	modelsDir := flag.String("models-dir", "", "directory of versioned model JSON files to load and hot-reload")
+	defaultModelID := flag.String("default-model", defaultModel.ID, "model id unpinned inference requests route to")
+	candidateModelID := flag.String("candidate-model", "", "model id to shadow-route a percentage of default traffic to")
+	candidateVersion := flag.String("candidate-version", "", "model version to pair with --candidate-model (latest loaded if empty)")
+	candidatePercent := flag.Float64("candidate-percent", 0, "fraction (0-1) of unpinned requests routed to the candidate model")
+	allowWeightsExport := flag.Bool("allow-weights-export", false, "let GET /api/models/{id}?weights=1 return a model's weights and bias")
+	tlsMode := flag.String("tls", "selfsigned", "TLS mode: none (plain HTTP), selfsigned (server.crt/server.key on disk), or autocert (Let's Encrypt via golang.org/x/crypto/acme/autocert)")
+	autocertHosts := flag.String("autocert-hosts", "", "comma-separated hostnames autocert.HostWhitelist is allowed to request certificates for; required when --tls=autocert")
+	autocertCacheDir := flag.String("autocert-cache-dir", "autocert-cache", "disk cache directory for autocert's issued certificates and ACME account key")
+	trustedProxies := flag.String("trusted-proxies", "", "comma-separated RemoteAddr hosts (e.g. a load balancer) allowed to supply the real client IP via X-Forwarded-For")
+	bootstrap := flag.Bool("bootstrap", true, "allow maybeBootstrap to refresh a ciphertext that has run low on levels; disable for predictable latency on deployments that never need it")
+	bootstrapMargin := flag.Int("bootstrap-margin", 0, "extra levels of headroom required, beyond the sigmoid stage's own minimum depth, before a ciphertext is considered low enough to bootstrap")
+	flag.Parse()
+
+	modelRegistry = modelregistry.NewRegistry(*defaultModelID, *allowWeightsExport)
+	if err := modelRegistry.Put(&defaultModel); err != nil {
+		log.Fatalf("failed to seed default model: %v", err)
+	}
+
+	if !*bootstrap {
+		bootstrappingEnabled = false
+		log.Printf("♻️  Bootstrapping disabled via --bootstrap=false")
+	}
+	bootstrapThresholdMargin = *bootstrapMargin
+
+	var err error
+	proofSigner, err = proof.NewSigner()
+	if err != nil {
+		log.Fatalf("failed to generate inference transcript signer: %v", err)
+	}
+
+	var proxies []string
+	if *trustedProxies != "" {
+		for _, p := range strings.Split(*trustedProxies, ",") {
+			proxies = append(proxies, strings.TrimSpace(p))
+		}
+	}
+	limiter = ratelimit.NewLimiter(ratelimit.DefaultLimits(runtime.NumCPU()), proxies)
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			limiter.Sweep(10 * time.Minute)
+		}
+	}()
+
+	if *modelsDir != "" {
+		if err := modelRegistry.LoadDir(*modelsDir); err != nil {
+			log.Printf("⚠️  %v", err)
+		}
+		stop := make(chan struct{})
+		if err := modelRegistry.Watch(*modelsDir, stop); err != nil {
+			log.Printf("⚠️  %v", err)
+		}
+	}
+
+	if *candidateModelID != "" {
+		modelRegistry.SetCandidate(*candidateModelID, *candidateVersion, *candidatePercent)
+		log.Printf("🅰️🅱️  A/B routing: %.0f%% of unpinned requests -> %s@%s", *candidatePercent*100, *candidateModelID, *candidateVersion)
+	}
+
 	router := mux.NewRouter()
 
 	router.HandleFunc("/health", healthHandler).Methods("GET")
+	router.HandleFunc("/api/server-info", serverInfoHandler).Methods("GET")
+	router.HandleFunc("/api/models", modelsHandler).Methods("GET")
+	router.HandleFunc("/api/models/{id}", modelHandler).Methods("GET")
+	router.HandleFunc("/api/model-commitment", modelCommitmentHandler).Methods("GET")
 	router.HandleFunc("/api/inference", inferenceHandler).Methods("POST", "OPTIONS")
+	// /api/inference/batch is inferenceHandler itself: the only thing that
+	// makes a request a "batch" one is setting batch_size > 1, so there's no
+	// separate handler body to maintain — this route just makes that usage
+	// discoverable at the URL level.
+	router.HandleFunc("/api/inference/batch", inferenceHandler).Methods("POST", "OPTIONS")
 	router.HandleFunc("/api/inference-packed", packedInferenceHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/session/keys", sessionKeysHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/api/session/stats", sessionStatsHandler).Methods("GET")
+	router.HandleFunc("/api/session/{id}", sessionInfoHandler).Methods("GET", "DELETE")
 
 	handler := enableCORS(router)
 
 	port := ":8080"
 
-	// HTTPS 모드 결정: TLS 인증서 파일이 존재하면 HTTPS, 없으면 HTTP
-	certFile := "server.crt"
-	keyFile := "server.key"
-	useHTTPS := fileExists(certFile) && fileExists(keyFile)
+	switch *tlsMode {
+	case "none":
+		log.Printf("⚠️  Server starting with HTTP on http://localhost%s (--tls=none)", port)
+		log.Printf("📊 Default model: %s@%s", defaultModel.ID, defaultModel.Version)
+		log.Printf("🔐 Ready to perform encrypted inference")
 
-	if useHTTPS {
-		log.Printf("🔒 Server starting with HTTPS on https://localhost%s", port)
-		log.Printf("📊 Model weights: %v, bias: %v", model.Weights, model.Bias)
+		if err := http.ListenAndServe(port, handler); err != nil {
+			log.Fatal(err)
+		}
+
+	case "autocert":
+		if *autocertHosts == "" {
+			log.Fatal("--tls=autocert requires --autocert-hosts (comma-separated public hostnames)")
+		}
+		hosts := strings.Split(*autocertHosts, ",")
+		for i := range hosts {
+			hosts[i] = strings.TrimSpace(hosts[i])
+		}
+
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(hosts...),
+			Cache:      autocert.DirCache(*autocertCacheDir),
+			Email:      os.Getenv("ACME_CONTACT_EMAIL"),
+		}
+
+		// ACME's HTTP-01 challenge must be answered on :80 over plain HTTP,
+		// alongside (not instead of) the real HTTPS listener below.
+		go func() {
+			if err := http.ListenAndServe(":80", manager.HTTPHandler(nil)); err != nil {
+				log.Printf("⚠️  autocert HTTP-01 challenge listener on :80 failed: %v", err)
+			}
+		}()
+
+		server := &http.Server{
+			Addr:      port,
+			Handler:   handler,
+			TLSConfig: manager.TLSConfig(),
+		}
+
+		log.Printf("🔒 Server starting with ACME-managed HTTPS for %v", hosts)
+		log.Printf("📊 Default model: %s@%s", defaultModel.ID, defaultModel.Version)
 		log.Printf("🔐 Ready to perform encrypted inference over TLS")
-		log.Printf("⚠️  Using self-signed certificate (browsers will show warnings)")
 
-		if err := http.ListenAndServeTLS(port, certFile, keyFile, handler); err != nil {
+		if err := server.ListenAndServeTLS("", ""); err != nil {
 			log.Fatal(err)
 		}
-	} else {
-		log.Printf("⚠️  Server starting with HTTP on http://localhost%s", port)
-		log.Printf("   (No TLS certificates found. Generate with: ./generate_cert.sh)")
-		log.Printf("📊 Model weights: %v, bias: %v", model.Weights, model.Bias)
-		log.Printf("🔐 Ready to perform encrypted inference")
 
-		if err := http.ListenAndServe(port, handler); err != nil {
+	default: // "selfsigned"
+		certFile := "server.crt"
+		keyFile := "server.key"
+		if !fileExists(certFile) || !fileExists(keyFile) {
+			log.Fatalf("--tls=selfsigned requires %s and %s (generate with ./generate_cert.sh), or pass --tls=none / --tls=autocert instead", certFile, keyFile)
+		}
+
+		log.Printf("🔒 Server starting with HTTPS on https://localhost%s", port)
+		log.Printf("📊 Default model: %s@%s", defaultModel.ID, defaultModel.Version)
+		log.Printf("🔐 Ready to perform encrypted inference over TLS")
+		log.Printf("⚠️  Using self-signed certificate (browsers will show warnings)")
+
+		if err := http.ListenAndServeTLS(port, certFile, keyFile, handler); err != nil {
 			log.Fatal(err)
 		}
 	}
@@ -0,0 +1,192 @@
+// Package proof lets a client cryptographically check that this baseline
+// server evaluated the model it claims to, against the ciphertexts the
+// client actually submitted — the standard "don't just trust the outsourced
+// computation" concern for encrypted inference. A ModelCommitment binds a
+// model's weights/bias to a public ID once at startup; a Transcript records
+// the ordered homomorphic operations one inference performed as SHA-256
+// hashes of each intermediate ciphertext; Sign/Verify wrap that transcript in
+// an Ed25519 signature so a client can detect both a forged transcript and
+// one that doesn't actually start from the ciphertexts it submitted.
+package proof
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// ModelCommitment publicly binds a model's weights/bias to an ID, computed
+// once when the model is loaded, so a client can later confirm (via
+// Transcript's recorded hashes) that an inference ran against this exact
+// model rather than a silently swapped-in one.
+type ModelCommitment struct {
+	ID           string `json:"id"` // hex-encoded SHA-256
+	ModelID      string `json:"model_id"`
+	ModelVersion string `json:"model_version"`
+	Nonce        string `json:"nonce"` // hex-encoded, so the commitment can't be brute-forced from public model metadata alone
+}
+
+// NewModelCommitment hashes modelID, modelVersion, weights and bias together
+// with a fresh random nonce into ModelCommitment.ID. The weights and bias
+// themselves are never exposed by the commitment, only bound by it.
+func NewModelCommitment(modelID, modelVersion string, weights []float64, bias float64) (*ModelCommitment, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("proof: generating commitment nonce: %v", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(modelID))
+	h.Write([]byte(modelVersion))
+	for _, w := range weights {
+		writeFloat64(h, w)
+	}
+	writeFloat64(h, bias)
+	h.Write(nonce[:])
+
+	return &ModelCommitment{
+		ID:           hex.EncodeToString(h.Sum(nil)),
+		ModelID:      modelID,
+		ModelVersion: modelVersion,
+		Nonce:        hex.EncodeToString(nonce[:]),
+	}, nil
+}
+
+func writeFloat64(h hash.Hash, f float64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], math.Float64bits(f))
+	h.Write(buf[:])
+}
+
+// Step is one recorded operation within a Transcript: its name (e.g.
+// "input_0", "first_weight_mul_rescale", "sigmoid") and the SHA-256 hash of
+// the ciphertext it produced, in Lattigo's own MarshalBinary encoding.
+type Step struct {
+	Op   string `json:"op"`
+	Hash string `json:"hash"` // hex-encoded SHA-256
+}
+
+// Transcript is the ordered record of every ciphertext an inference touched,
+// tied to the ModelCommitment it was evaluated under. Its leading steps
+// (named input_0, input_1, ...) are the client's own submitted ciphertexts,
+// recorded via RecordInput, so Verify can confirm they appear unmodified
+// before any server-side operation.
+type Transcript struct {
+	ModelCommitmentID string `json:"model_commitment_id"`
+	Steps             []Step `json:"steps"`
+}
+
+// NewTranscript starts an empty transcript tied to commitmentID.
+func NewTranscript(commitmentID string) *Transcript {
+	return &Transcript{ModelCommitmentID: commitmentID}
+}
+
+// RecordInput appends one of the client's submitted ciphertexts as a
+// transcript leaf, named input_<index>.
+func (t *Transcript) RecordInput(index int, ct *rlwe.Ciphertext) error {
+	return t.record(fmt.Sprintf("input_%d", index), ct)
+}
+
+// Record appends the result of one server-side operation to the transcript.
+func (t *Transcript) Record(op string, ct *rlwe.Ciphertext) error {
+	return t.record(op, ct)
+}
+
+func (t *Transcript) record(op string, ct *rlwe.Ciphertext) error {
+	b, err := ct.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("proof: hashing transcript step %q: %v", op, err)
+	}
+	sum := sha256.Sum256(b)
+	t.Steps = append(t.Steps, Step{Op: op, Hash: hex.EncodeToString(sum[:])})
+	return nil
+}
+
+// canonicalBytes serializes t deterministically for signing and
+// verification: Step and Transcript's field order is fixed by their struct
+// definitions, so encoding/json produces the same bytes for the same value
+// on every call.
+func (t *Transcript) canonicalBytes() ([]byte, error) {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("proof: encoding transcript: %v", err)
+	}
+	return b, nil
+}
+
+// Signer holds the server's Ed25519 keypair used to sign transcripts. A
+// client holding PublicKey() can detect a tampered or forged transcript
+// without any other shared secret.
+type Signer struct {
+	priv ed25519.PrivateKey
+	pub  ed25519.PublicKey
+}
+
+// NewSigner generates a fresh Ed25519 keypair for the server's lifetime.
+func NewSigner() (*Signer, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("proof: generating signer keypair: %v", err)
+	}
+	return &Signer{priv: priv, pub: pub}, nil
+}
+
+// PublicKey returns the key clients must verify transcripts against, e.g.
+// published alongside GET /api/model-commitment.
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.pub
+}
+
+// Sign signs t's canonical encoding.
+func (s *Signer) Sign(t *Transcript) ([]byte, error) {
+	b, err := t.canonicalBytes()
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.Sign(s.priv, b), nil
+}
+
+// Verify checks sig against t under pub, then confirms that every one of
+// clientInputs appears, in order, as t's leading input_i steps — so a client
+// holding only its own ciphertexts (it never sees the server's intermediate
+// values) can still detect a transcript that was validly signed but doesn't
+// actually start from what it submitted.
+func Verify(pub ed25519.PublicKey, t *Transcript, sig []byte, clientInputs []*rlwe.Ciphertext) error {
+	b, err := t.canonicalBytes()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, b, sig) {
+		return fmt.Errorf("proof: invalid transcript signature")
+	}
+
+	if len(t.Steps) < len(clientInputs) {
+		return fmt.Errorf("proof: transcript has %d steps, too few for %d submitted inputs", len(t.Steps), len(clientInputs))
+	}
+
+	for i, ct := range clientInputs {
+		wantOp := fmt.Sprintf("input_%d", i)
+		if t.Steps[i].Op != wantOp {
+			return fmt.Errorf("proof: transcript step %d is %q, expected %q", i, t.Steps[i].Op, wantOp)
+		}
+
+		b, err := ct.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("proof: hashing client input %d: %v", i, err)
+		}
+		sum := sha256.Sum256(b)
+		if t.Steps[i].Hash != hex.EncodeToString(sum[:]) {
+			return fmt.Errorf("proof: transcript input %d hash does not match the submitted ciphertext", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+// Package ratelimit throttles the logn14 baseline's inference endpoints,
+// where a single request is six rescales plus a degree-5+ Chebyshev sigmoid
+// — expensive enough that an un-throttled handler is trivially DoS-able with
+// nothing more than a handful of 10MB ciphertext blobs. Limiter combines a
+// per-client-IP token bucket (golang.org/x/time/rate), an optional
+// per-session token bucket layered on top of it, and a global concurrency
+// semaphore sized to the host's CPU count, so a caller hitting any one of
+// the three limits gets a 429/503 with a Retry-After hint instead of queuing
+// behind (or starving) every other client's inference.
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limits bundles the knobs Limiter enforces.
+type Limits struct {
+	PerClientRPS      rate.Limit
+	PerClientBurst    int
+	GlobalConcurrency int
+}
+
+// DefaultLimits throttles a single client (by IP, or by session once
+// AllowSession is used) to one request every 2 seconds with a burst of 2 —
+// CKKS inference isn't something a legitimate client calls many times a
+// second — and caps total in-flight inferences at globalConcurrency so a
+// wave of distinct clients still can't saturate every CPU core at once.
+func DefaultLimits(globalConcurrency int) Limits {
+	return Limits{
+		PerClientRPS:      rate.Every(2 * time.Second),
+		PerClientBurst:    2,
+		GlobalConcurrency: globalConcurrency,
+	}
+}
+
+// Limiter is this package's rate limiter: one token bucket per client IP,
+// one per session once a caller knows a request's session_id, and a
+// buffered-channel semaphore bounding how many inferences run at once.
+type Limiter struct {
+	limits Limits
+
+	mu       sync.Mutex
+	perIP    map[string]*rate.Limiter
+	lastSeen map[string]time.Time
+
+	sessionMu       sync.Mutex
+	perSession      map[string]*rate.Limiter
+	sessionLastSeen map[string]time.Time
+
+	sem chan struct{}
+
+	trustedProxies map[string]struct{}
+}
+
+// NewLimiter builds a Limiter enforcing limits. trustedProxies is the set
+// of RemoteAddr hosts (e.g. a load balancer's IP) allowed to supply a
+// client IP via X-Forwarded-For; any other caller is limited by its own
+// RemoteAddr regardless of what X-Forwarded-For claims, so a client can't
+// spoof its way around the per-IP bucket.
+func NewLimiter(limits Limits, trustedProxies []string) *Limiter {
+	proxies := make(map[string]struct{}, len(trustedProxies))
+	for _, p := range trustedProxies {
+		proxies[p] = struct{}{}
+	}
+	return &Limiter{
+		limits:          limits,
+		perIP:           make(map[string]*rate.Limiter),
+		lastSeen:        make(map[string]time.Time),
+		perSession:      make(map[string]*rate.Limiter),
+		sessionLastSeen: make(map[string]time.Time),
+		sem:             make(chan struct{}, limits.GlobalConcurrency),
+		trustedProxies:  proxies,
+	}
+}
+
+// ClientIP returns the IP address Allow should key off of for r: its
+// RemoteAddr, unless RemoteAddr is a configured trusted proxy, in which
+// case the left-most (closest-to-client) address in X-Forwarded-For is
+// used instead.
+func (l *Limiter) ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if _, trusted := l.trustedProxies[host]; trusted {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			if first := strings.TrimSpace(strings.Split(xff, ",")[0]); first != "" {
+				return first
+			}
+		}
+	}
+
+	return host
+}
+
+// Allow reports whether a request from ip may proceed. If not, the
+// returned duration is how long the caller should wait before retrying
+// (suitable for a Retry-After header).
+func (l *Limiter) Allow(ip string) (retryAfter time.Duration, ok bool) {
+	now := time.Now()
+
+	l.mu.Lock()
+	lim, seen := l.perIP[ip]
+	if !seen {
+		lim = rate.NewLimiter(l.limits.PerClientRPS, l.limits.PerClientBurst)
+		l.perIP[ip] = lim
+	}
+	l.lastSeen[ip] = now
+	l.mu.Unlock()
+
+	return reserve(lim, now)
+}
+
+// AllowSession applies a second token bucket keyed by sessionID, on top of
+// Allow's per-IP one, so a single session can't burn through a whole
+// shared-NAT IP's quota by itself. A blank sessionID always passes — not
+// every request has one yet (e.g. before POST /api/session/keys).
+func (l *Limiter) AllowSession(sessionID string) (retryAfter time.Duration, ok bool) {
+	if sessionID == "" {
+		return 0, true
+	}
+
+	now := time.Now()
+
+	l.sessionMu.Lock()
+	lim, seen := l.perSession[sessionID]
+	if !seen {
+		lim = rate.NewLimiter(l.limits.PerClientRPS, l.limits.PerClientBurst)
+		l.perSession[sessionID] = lim
+	}
+	l.sessionLastSeen[sessionID] = now
+	l.sessionMu.Unlock()
+
+	return reserve(lim, now)
+}
+
+func reserve(lim *rate.Limiter, now time.Time) (time.Duration, bool) {
+	r := lim.ReserveN(now, 1)
+	if !r.OK() {
+		return 0, false
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.Cancel()
+		return delay, false
+	}
+	return 0, true
+}
+
+// TryAcquire claims one of GlobalConcurrency concurrent-inference slots,
+// returning false immediately — never blocking — if none are free. A
+// blocking acquire would just turn the semaphore into an unbounded queue of
+// multi-second CKKS evaluations instead of the hard cap it's meant to be;
+// callers should respond 503 on false, not wait.
+func (l *Limiter) TryAcquire() bool {
+	select {
+	case l.sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release frees a slot claimed by TryAcquire.
+func (l *Limiter) Release() {
+	<-l.sem
+}
+
+// Sweep drops any per-IP or per-session bucket untouched for longer than
+// idleTTL, so neither perIP nor perSession grows without bound over a
+// long-lived server's lifetime. perSession in particular sits behind an
+// attacker-controlled key — a client can mint a fresh session_id on every
+// request — so it needs the same eviction perIP gets. Callers run it
+// periodically on a ticker.
+func (l *Limiter) Sweep(idleTTL time.Duration) {
+	cutoff := time.Now().Add(-idleTTL)
+
+	l.mu.Lock()
+	for ip, seen := range l.lastSeen {
+		if seen.Before(cutoff) {
+			delete(l.perIP, ip)
+			delete(l.lastSeen, ip)
+		}
+	}
+	l.mu.Unlock()
+
+	l.sessionMu.Lock()
+	for sessionID, seen := range l.sessionLastSeen {
+		if seen.Before(cutoff) {
+			delete(l.perSession, sessionID)
+			delete(l.sessionLastSeen, sessionID)
+		}
+	}
+	l.sessionMu.Unlock()
+}
+
+// Status is this Limiter's current configuration and load, exposed via
+// GET /health so an operator can see effective quotas without reading
+// server flags.
+type Status struct {
+	PerClientRPS      float64 `json:"per_client_rps"`
+	PerClientBurst    int     `json:"per_client_burst"`
+	GlobalConcurrency int     `json:"global_concurrency"`
+	InFlight          int     `json:"in_flight"`
+}
+
+// Status reports l's current configuration and in-flight count.
+func (l *Limiter) Status() Status {
+	return Status{
+		PerClientRPS:      float64(l.limits.PerClientRPS),
+		PerClientBurst:    l.limits.PerClientBurst,
+		GlobalConcurrency: l.limits.GlobalConcurrency,
+		InFlight:          len(l.sem),
+	}
+}
@@ -0,0 +1,145 @@
+// apiserver is the running process that mounts internal/server's route
+// registrars (RegisterAsyncRoutes, RegisterKeyRoutes, RegisterMetricsRoutes,
+// RegisterMPCRoutes, RegisterStreamRoutes) and internal/grpcapi.Server onto
+// a listening HTTP/gRPC server — each of those was previously just a
+// mux/service builder nothing in this tree ever called. cmd/worker remains
+// the separate NATS consumer that answers the requests RegisterAsyncRoutes
+// enqueues.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+
+	"ckks-credit/internal/app"
+	"ckks-credit/internal/crypto"
+	"ckks-credit/internal/grpcapi"
+	pb "ckks-credit/internal/grpcapi/proto"
+	"ckks-credit/internal/keystore"
+	"ckks-credit/internal/queue"
+	"ckks-credit/internal/server"
+)
+
+func main() {
+	httpAddr := flag.String("http-addr", ":8080", "HTTP listen address")
+	natsURL := flag.String("nats-url", "nats://localhost:4222", "NATS server URL, backing the async inference routes")
+	keysDir := flag.String("keys-dir", "keys", "keystore directory backing /api/v1/keys/rotate")
+	keysRetain := flag.Int("keys-retain", keystore.DefaultRetain, "previous key versions to keep online for grace-period decryption")
+	adminToken := flag.String("admin-token", "", "shared secret required via X-Admin-Token on /api/v1/keys/rotate (required; also set KEYSTORE_PASSPHRASE to encrypt the keystore at rest)")
+	grpcAddr := flag.String("grpc-addr", ":50051", "gRPC listen address")
+	flag.Parse()
+
+	application, err := app.NewApplication()
+	if err != nil {
+		log.Fatalf("apiserver: failed to build application: %v", err)
+	}
+	params := application.CreditService.Params()
+
+	q, err := queue.Dial(*natsURL)
+	if err != nil {
+		log.Fatalf("apiserver: failed to dial NATS at %s: %v", *natsURL, err)
+	}
+	defer q.Close()
+
+	keyStore, err := keystore.NewStore(*keysDir, params, nil, *keysRetain)
+	if err != nil {
+		log.Fatalf("apiserver: failed to open keystore at %s: %v", *keysDir, err)
+	}
+
+	metricsSource, err := newMetricsSource(params)
+	if err != nil {
+		log.Fatalf("apiserver: failed to build metrics source: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	asyncCoordinator := server.NewAsyncCoordinator(q, q)
+	server.RegisterAsyncRoutes(mux, asyncCoordinator)
+	if err := server.RegisterKeyRoutes(mux, keyStore, *adminToken); err != nil {
+		log.Fatalf("apiserver: %v", err)
+	}
+	server.RegisterMetricsRoutes(mux, metricsSource)
+	mpcCoordinator := server.NewMPCCoordinator(params)
+	server.RegisterMPCRoutes(mux, mpcCoordinator)
+
+	scorer, err := app.NewCreditScorer(params)
+	if err != nil {
+		log.Fatalf("apiserver: failed to build credit scorer for streaming: %v", err)
+	}
+	streamCoordinator := server.NewStreamCoordinator(params, scorer)
+	server.RegisterStreamRoutes(mux, streamCoordinator)
+
+	go serveGRPC(*grpcAddr, application, streamCoordinator)
+
+	log.Printf("apiserver: listening on %s", *httpAddr)
+	log.Fatal(http.ListenAndServe(*httpAddr, mux))
+}
+
+// serveGRPC mounts internal/grpcapi.Server — otherwise never registered
+// anywhere in this tree — onto a listening grpc.Server, so
+// cmd/grpc-client-example (which only ever dials a server that doesn't
+// exist) has something to actually talk to. streamCoordinator is the same
+// one backing /api/v1/score/stream, so InferenceStream avoids re-sending
+// evaluation keys on every call the same way that HTTP route does.
+func serveGRPC(addr string, application *app.Application, streamCoordinator *server.StreamCoordinator) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("apiserver: failed to listen on %s for gRPC: %v", addr, err)
+	}
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterCreditScoringServiceServer(grpcServer, grpcapi.NewServer(application, streamCoordinator))
+
+	log.Printf("apiserver: gRPC listening on %s", addr)
+	log.Fatal(grpcServer.Serve(lis))
+}
+
+// newMetricsSource builds the crypto.Diagnostics/Workload pair GET /metrics
+// re-runs on every scrape: a single-weight weighted-multiply workload over
+// the running service's own CreditScorer weights, mirroring
+// cmd/grpc-client-example's runDiagnostics.
+func newMetricsSource(params ckks.Parameters) (*server.MetricsSource, error) {
+	km, err := crypto.NewKeyManager(params)
+	if err != nil {
+		return nil, err
+	}
+	evaluator := ckks.NewEvaluator(params, rlwe.NewMemEvaluationKeySet(km.Key()))
+	diagnostics := crypto.NewDiagnostics(params, km, evaluator)
+
+	scorer, err := app.NewCreditScorer(params)
+	if err != nil {
+		return nil, err
+	}
+	weight := scorer.Weights()[0]
+
+	workload := crypto.Workload{
+		Features: []float64{0.5},
+		Eval: func(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+			values := make([]complex128, params.MaxSlots())
+			values[0] = complex(weight, 0)
+			pt := ckks.NewPlaintext(params, ct.Level())
+			if err := ckks.NewEncoder(params).Encode(values, pt); err != nil {
+				return nil, err
+			}
+			result, err := evaluator.MulNew(ct, pt)
+			if err != nil {
+				return nil, err
+			}
+			if err := evaluator.Rescale(result, result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		},
+		Reference: func(features []float64) float64 {
+			return features[0] * weight
+		},
+	}
+
+	return &server.MetricsSource{Diagnostics: diagnostics, Workloads: []crypto.Workload{workload}, Reps: 3}, nil
+}
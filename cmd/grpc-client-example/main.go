@@ -0,0 +1,401 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+
+	"ckks-credit/internal/app"
+	"ckks-credit/internal/crypto"
+	pb "ckks-credit/internal/grpcapi/proto"
+	"ckks-credit/pkg/batch"
+	"ckks-credit/pkg/client"
+)
+
+// grpc-client-example replaces e2e_baseline.go's http.Post loop with the
+// bidirectional-streaming gRPC path from internal/grpcapi and the binary
+// streaming path from pkg/client, scoring the same 5 test cases, and
+// reports each path's total bytes-on-the-wire and time so operators can
+// compare the streaming-bytes transports against JSON+base64 before
+// switching a client over.
+type testCase struct {
+	name        string
+	age         float64
+	income      float64
+	loanAmount  float64
+	creditScore float64
+	debtRatio   float64
+}
+
+var testCases = []testCase{
+	{name: "Good Credit - High Income", age: 0.5, income: 0.75, loanAmount: 0.3, creditScore: 0.85, debtRatio: 0.2},
+	{name: "Poor Credit - Low Income", age: 0.3, income: 0.2, loanAmount: 0.6, creditScore: 0.3, debtRatio: 0.8},
+	{name: "Excellent Credit - High Income Low Debt", age: 0.6, income: 0.95, loanAmount: 0.2, creditScore: 0.95, debtRatio: 0.1},
+	{name: "Average Credit - Middle Income", age: 0.4, income: 0.5, loanAmount: 0.4, creditScore: 0.6, debtRatio: 0.4},
+	{name: "Young Professional - Low History", age: 0.15, income: 0.6, loanAmount: 0.5, creditScore: 0.55, debtRatio: 0.35},
+}
+
+func main() {
+	grpcAddr := flag.String("grpc-addr", "localhost:50051", "internal/grpcapi server address")
+	restURL := flag.String("rest-url", "http://localhost:8080/api/v1/score/infer", "JSON REST inference endpoint")
+	streamURL := flag.String("stream-url", "http://localhost:8080", "pkg/client binary streaming base URL")
+	flag.Parse()
+
+	params, err := ckks.NewParametersFromLiteral(ckks.ParametersLiteral{
+		LogN:            13,
+		LogQ:            []int{60, 40, 40, 40, 40, 40, 40, 40, 60},
+		LogP:            []int{61},
+		LogDefaultScale: 40,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	kgen := ckks.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+	rlkBytes, _ := rlk.MarshalBinary()
+
+	// rotSteps mirrors internal/app.NewInferenceEngine's rotate-and-sum tree
+	// for CreditScorer's 3 weights: rotations 1, 2 up to nextPowerOfTwo(3)/2.
+	rotSteps := []int{1, 2}
+	galEls := make([]uint64, len(rotSteps))
+	for i, step := range rotSteps {
+		galEls[i] = params.GaloisElement(step)
+	}
+	rotk := kgen.GenGaloisKeysNew(galEls, sk)
+
+	encoder := ckks.NewEncoder(params)
+	encryptor := ckks.NewEncryptor(params, sk)
+
+	fmt.Println("🧪 gRPC / binary-stream vs JSON REST throughput comparison")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("Relinearization key: %d bytes\n\n", len(rlkBytes))
+
+	conn, err := grpc.NewClient(*grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		fmt.Printf("❌ Failed to dial gRPC server at %s: %v\n", *grpcAddr, err)
+		return
+	}
+	defer conn.Close()
+	grpcClient := pb.NewCreditScoringServiceClient(conn)
+
+	streamClient := client.New(*streamURL, nil)
+	streamSessionID, err := streamClient.RegisterKeys(context.Background(), rlk, rotk)
+	if err != nil {
+		fmt.Printf("❌ Failed to register keys with stream server at %s: %v\n", *streamURL, err)
+		return
+	}
+	fmt.Printf("Stream session: %s (rlk+%d rotation keys sent once)\n\n", streamSessionID, len(rotk))
+
+	var grpcTotalTime, restTotalTime, streamTotalTime time.Duration
+	var grpcTotalBytes, restTotalBytes, streamTotalBytes int
+
+	for i, tc := range testCases {
+		fmt.Printf("Test %d/%d: %s\n", i+1, len(testCases), tc.name)
+
+		values := make([]complex128, params.MaxSlots())
+		features := []float64{tc.age, tc.income, tc.loanAmount, tc.creditScore, tc.debtRatio}
+		for j, f := range features {
+			values[j] = complex(f, 0)
+		}
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		encoder.Encode(values, pt)
+		ct, _ := encryptor.EncryptNew(pt)
+		ctBytes, _ := ct.MarshalBinary()
+
+		grpcTime, grpcBytes, err := scoreOverGRPC(grpcClient, tc.name, ctBytes, rlkBytes)
+		if err != nil {
+			fmt.Printf("  ❌ gRPC path failed: %v\n", err)
+		} else {
+			fmt.Printf("  gRPC:   %.2f ms, %d bytes on the wire\n", float64(grpcTime.Microseconds())/1000.0, grpcBytes)
+			grpcTotalTime += grpcTime
+			grpcTotalBytes += grpcBytes
+		}
+
+		restTime, restBytes, err := scoreOverREST(*restURL, ctBytes, rlkBytes)
+		if err != nil {
+			fmt.Printf("  ❌ REST path failed: %v\n", err)
+		} else {
+			fmt.Printf("  REST:   %.2f ms, %d bytes on the wire (base64+JSON)\n", float64(restTime.Microseconds())/1000.0, restBytes)
+			restTotalTime += restTime
+			restTotalBytes += restBytes
+		}
+
+		streamTime, streamBytes, err := scoreOverStream(streamClient, streamSessionID, ct)
+		if err != nil {
+			fmt.Printf("  ❌ Stream path failed: %v\n", err)
+		} else {
+			fmt.Printf("  Stream: %.2f ms, %d bytes on the wire (raw, no RLK re-upload)\n", float64(streamTime.Microseconds())/1000.0, streamBytes)
+			streamTotalTime += streamTime
+			streamTotalBytes += streamBytes
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("gRPC total:   %.2f ms, %d bytes\n", float64(grpcTotalTime.Microseconds())/1000.0, grpcTotalBytes)
+	fmt.Printf("REST total:   %.2f ms, %d bytes\n", float64(restTotalTime.Microseconds())/1000.0, restTotalBytes)
+	fmt.Printf("Stream total: %.2f ms, %d bytes (rlk: %d bytes sent once via RegisterKeys)\n",
+		float64(streamTotalTime.Microseconds())/1000.0, streamTotalBytes, len(rlkBytes))
+	if restTotalBytes > 0 {
+		fmt.Printf("gRPC bytes-on-the-wire saved:   %.1f%%\n", (1-float64(grpcTotalBytes)/float64(restTotalBytes))*100)
+		fmt.Printf("Stream bytes-on-the-wire saved: %.1f%%\n", (1-float64(streamTotalBytes)/float64(restTotalBytes))*100)
+	}
+
+	runDiagnostics(params)
+	runBatchThroughput(params, sk)
+}
+
+// runBatchThroughput scores a batch of applicants in a single ciphertext via
+// pkg/batch and reports applicants/sec, the SIMD-packing payoff
+// params.MaxSlots()-wide batching gives over the 1-applicant-per-ciphertext
+// paths exercised above.
+func runBatchThroughput(params ckks.Parameters, sk *rlwe.SecretKey) {
+	scorer, err := app.NewCreditScorer(params)
+	if err != nil {
+		fmt.Printf("❌ Failed to build credit scorer for batch throughput: %v\n", err)
+		return
+	}
+
+	evaluator, err := batch.NewEvaluator(params, scorer, sk, app.DefaultSigmoidRange)
+	if err != nil {
+		fmt.Printf("❌ Failed to build batch evaluator: %v\n", err)
+		return
+	}
+
+	numApplicants := evaluator.MaxBatchSize()
+	const demoCap = 64 // keep the demo's keygen/runtime reasonable regardless of MaxSlots
+	if numApplicants > demoCap {
+		numApplicants = demoCap
+	}
+
+	applicants := make([][]float64, numApplicants)
+	for i := range applicants {
+		applicants[i] = []float64{0.3 + 0.01*float64(i%10), 0.2, 0.5}
+	}
+
+	start := time.Now()
+	results, err := evaluator.Score(applicants)
+	if err != nil {
+		fmt.Printf("❌ Batch scoring failed: %v\n", err)
+		return
+	}
+	elapsed := time.Since(start)
+
+	decryptor := batch.NewDecryptor(params, sk, len(scorer.Weights()))
+	totalScored := 0
+	offset := 0
+	for _, ct := range results {
+		chunkSize := numApplicants - offset
+		if chunkSize > evaluator.MaxBatchSize() {
+			chunkSize = evaluator.MaxBatchSize()
+		}
+		scores, err := decryptor.Unpack(ct, chunkSize)
+		if err != nil {
+			fmt.Printf("❌ Failed to unpack batch chunk: %v\n", err)
+			return
+		}
+		totalScored += len(scores)
+		offset += chunkSize
+	}
+
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Println("📦 Batch Scoring Throughput (pkg/batch)")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("Applicants per ciphertext: %d (MaxSlots=%d / %d features)\n",
+		evaluator.MaxBatchSize(), params.MaxSlots(), len(scorer.Weights()))
+	fmt.Printf("Scored %d applicants in %.2fms across %d ciphertext(s)\n",
+		totalScored, float64(elapsed.Microseconds())/1000.0, len(results))
+	fmt.Printf("Throughput: %.1f applicants/sec\n", float64(totalScored)/elapsed.Seconds())
+}
+
+// runDiagnostics replaces the ad-hoc "Aggregate Performance Metrics" print
+// block with internal/crypto.Diagnostics' calibrated per-stage latency
+// (mean/stddev/p95), ciphertext size, remaining modulus-chain depth, and
+// precision-loss numbers, driven over the same 5 test cases.
+func runDiagnostics(params ckks.Parameters) {
+	km, err := crypto.NewKeyManager(params)
+	if err != nil {
+		fmt.Printf("❌ Failed to build diagnostics key manager: %v\n", err)
+		return
+	}
+	evaluator := ckks.NewEvaluator(params, rlwe.NewMemEvaluationKeySet(km.Key()))
+	diagnostics := crypto.NewDiagnostics(params, km, evaluator)
+
+	const weight = 0.4
+	workloads := make([]crypto.Workload, len(testCases))
+	for i, tc := range testCases {
+		workloads[i] = crypto.Workload{
+			Features: []float64{tc.age, tc.income, tc.loanAmount, tc.creditScore, tc.debtRatio},
+			Eval: func(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+				values := make([]complex128, params.MaxSlots())
+				values[0] = complex(weight, 0)
+				pt := ckks.NewPlaintext(params, ct.Level())
+				if err := ckks.NewEncoder(params).Encode(values, pt); err != nil {
+					return nil, err
+				}
+				result, err := evaluator.MulNew(ct, pt)
+				if err != nil {
+					return nil, err
+				}
+				if err := evaluator.Rescale(result, result); err != nil {
+					return nil, err
+				}
+				return result, nil
+			},
+			Reference: func(features []float64) float64 {
+				return features[0] * weight
+			},
+		}
+	}
+
+	report, err := diagnostics.Run(workloads, 3)
+	if err != nil {
+		fmt.Printf("❌ Diagnostics run failed: %v\n", err)
+		return
+	}
+
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Println("📊 Calibrated Diagnostics (internal/crypto.Diagnostics)")
+	fmt.Println(strings.Repeat("=", 60))
+	printStage("Encode ", report.Encode)
+	printStage("Encrypt", report.Encrypt)
+	printStage("Eval   ", report.Eval)
+	printStage("Decrypt", report.Decrypt)
+	fmt.Printf("Ciphertext size:  %d bytes\n", report.CiphertextBytes)
+	fmt.Printf("Remaining levels: %d of %d\n", report.RemainingLevels, params.MaxLevel())
+	fmt.Printf("Precision loss:   avg %.2e, max %.2e\n", report.PrecisionLossAvg, report.PrecisionLossMax)
+}
+
+func printStage(label string, s crypto.StageStats) {
+	fmt.Printf("%s: mean %.3fms, stddev %.3fms, p95 %.3fms (n=%d)\n", label, s.Mean, s.StdDev, s.P95, s.Count)
+}
+
+// scoreOverStream sends ct to pkg/client's Score, which streams the raw
+// ciphertext bytes under the session RegisterKeys already established,
+// instead of base64-encoding it and re-sending rlk on every call.
+func scoreOverStream(c *client.Client, sessionID string, ct *rlwe.Ciphertext) (time.Duration, int, error) {
+	start := time.Now()
+	sent := ct.BinarySize()
+
+	result, err := c.Score(context.Background(), sessionID, ct)
+	if err != nil {
+		return 0, 0, fmt.Errorf("score: %v", err)
+	}
+
+	return time.Since(start), sent + result.BinarySize(), nil
+}
+
+// scoreOverGRPC streams ct (and, on the first call, rlk) to internal/grpcapi's
+// Score RPC in chunkSize-sized frames and waits for the final score frame.
+func scoreOverGRPC(client pb.CreditScoringServiceClient, requestID string, ctBytes, rlkBytes []byte) (time.Duration, int, error) {
+	const chunkSize = 1 << 20
+
+	start := time.Now()
+	stream, err := client.Score(context.Background())
+	if err != nil {
+		return 0, 0, fmt.Errorf("open stream: %v", err)
+	}
+
+	sent := 0
+	ctChunks := splitBytes(ctBytes, chunkSize)
+	for i, part := range ctChunks {
+		rlkPart := []byte(nil)
+		if i == 0 {
+			rlkPart = rlkBytes
+		}
+		if err := stream.Send(&pb.EncryptedFeatureChunk{
+			RequestId:      requestID,
+			Sequence:       uint32(i),
+			FinalChunk:     i == len(ctChunks)-1,
+			CiphertextPart: part,
+			RlkPart:        rlkPart,
+		}); err != nil {
+			return 0, 0, fmt.Errorf("send chunk %d: %v", i, err)
+		}
+		sent += len(part) + len(rlkPart)
+	}
+	if err := stream.CloseSend(); err != nil {
+		return 0, 0, fmt.Errorf("close send: %v", err)
+	}
+
+	received := 0
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("recv: %v", err)
+		}
+		if resp.Error != "" {
+			return 0, 0, fmt.Errorf("server error: %s", resp.Error)
+		}
+		received += len(resp.CiphertextPart)
+		if resp.FinalChunk {
+			break
+		}
+	}
+
+	return time.Since(start), sent + received, nil
+}
+
+// scoreOverREST mirrors baseline/logn14/e2e_baseline.go's http.Post call,
+// base64-encoding the ciphertext and relinearization key inside a JSON body.
+func scoreOverREST(url string, ctBytes, rlkBytes []byte) (time.Duration, int, error) {
+	start := time.Now()
+
+	payload := map[string]any{
+		"encryptedFeatures":  base64.StdEncoding.EncodeToString(ctBytes),
+		"relinearizationKey": base64.StdEncoding.EncodeToString(rlkBytes),
+	}
+	requestJSON, err := json.Marshal(payload)
+	if err != nil {
+		return 0, 0, fmt.Errorf("marshal request: %v", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(requestJSON))
+	if err != nil {
+		return 0, 0, fmt.Errorf("post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, 0, fmt.Errorf("read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("backend returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	return time.Since(start), len(requestJSON) + len(body), nil
+}
+
+func splitBytes(data []byte, size int) [][]byte {
+	if len(data) == 0 {
+		return [][]byte{{}}
+	}
+	var out [][]byte
+	for start := 0; start < len(data); start += size {
+		end := start + size
+		if end > len(data) {
+			end = len(data)
+		}
+		out = append(out, data[start:end])
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+
+	"ckks-credit/internal/keystore"
+)
+
+// keystore-rotate generates a new CKKS key set under an existing (or
+// freshly created) keystore directory and reports the version it was
+// tagged with, without needing the server itself running. It's the
+// operational counterpart to POST /api/v1/keys/rotate for operators who'd
+// rather rotate from a cron job or deploy hook than curl the admin endpoint.
+func main() {
+	dir := flag.String("dir", "keys", "keystore directory")
+	retain := flag.Int("retain", keystore.DefaultRetain, "previous key versions to keep online for grace-period decryption")
+	flag.Parse()
+
+	paramsLit := ckks.ParametersLiteral{
+		LogN:            13,
+		LogQ:            []int{60, 40, 40, 40, 40, 40, 40, 40, 60},
+		LogP:            []int{61},
+		LogDefaultScale: 40,
+	}
+	params, err := ckks.NewParametersFromLiteral(paramsLit)
+	if err != nil {
+		log.Fatalf("keystore-rotate: failed to create CKKS parameters: %v", err)
+	}
+
+	store, err := keystore.NewStore(*dir, params, nil, *retain)
+	if err != nil {
+		log.Fatalf("keystore-rotate: failed to open keystore at %s: %v", *dir, err)
+	}
+
+	ks, err := store.Rotate()
+	if err != nil {
+		log.Fatalf("keystore-rotate: rotation failed: %v", err)
+	}
+
+	fmt.Printf("rotated to key version %d at %s\n", ks.Version, ks.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+}
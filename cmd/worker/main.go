@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+
+	"ckks-credit/internal/app"
+	"ckks-credit/internal/queue"
+)
+
+// worker subscribes to queue.RequestTopic and runs the same weighted
+// dot-product + bias pipeline internal/server's synchronous handlers do,
+// but against an evaluator built from each request's own rlk/galois keys
+// (the same "client brings its own keys" model internal/server's
+// StreamCoordinator uses) instead of a server-held secret key. Decoupling
+// evaluation from an HTTP request's lifetime this way means an inference
+// that would blow past an HTTP client's timeout under load just takes
+// longer to reply on its topic instead of failing the request, and lets
+// operators scale worker processes independently of the HTTP frontend.
+func main() {
+	natsURL := flag.String("nats-url", "nats://localhost:4222", "NATS server URL")
+	flag.Parse()
+
+	paramsLit := ckks.ParametersLiteral{
+		LogN:            12,
+		LogQ:            []int{38, 32, 32, 32, 32},
+		LogP:            []int{39},
+		LogDefaultScale: 32,
+	}
+	params, err := ckks.NewParametersFromLiteral(paramsLit)
+	if err != nil {
+		log.Fatalf("worker: failed to create CKKS parameters: %v", err)
+	}
+
+	scorer, err := app.NewCreditScorer(params)
+	if err != nil {
+		log.Fatalf("worker: failed to build credit scorer: %v", err)
+	}
+
+	q, err := queue.Dial(*natsURL)
+	if err != nil {
+		log.Fatalf("worker: %v", err)
+	}
+	defer q.Close()
+
+	ctx := context.Background()
+	if err := q.Subscribe(ctx, queue.RequestTopic, func(data []byte) {
+		handleRequest(q, params, scorer, data)
+	}); err != nil {
+		log.Fatalf("worker: subscribe to %s failed: %v", queue.RequestTopic, err)
+	}
+
+	log.Printf("worker: listening on %q at %s", queue.RequestTopic, *natsURL)
+	select {}
+}
+
+// handleRequest decodes one InferenceRequestMessage, scores it, and
+// publishes an InferenceResultMessage to its reply topic regardless of
+// whether scoring succeeded, so a waiting caller never hangs forever.
+func handleRequest(q *queue.NATSQueue, params ckks.Parameters, scorer *app.CreditScorer, data []byte) {
+	start := time.Now()
+
+	var req queue.InferenceRequestMessage
+	if err := json.Unmarshal(data, &req); err != nil {
+		log.Printf("worker: failed to decode request: %v", err)
+		return
+	}
+
+	result := queue.InferenceResultMessage{RequestID: req.RequestID}
+	defer func() {
+		result.TimingBreakdown.TotalMs = msSince(start)
+		if err := q.Publish(req.ReplyTopic, result); err != nil {
+			log.Printf("worker: failed to publish result for %s: %v", req.RequestID, err)
+		}
+	}()
+
+	decodeStart := time.Now()
+	evaluator, err := buildEvaluator(params, req.RelinearizationKey, req.GaloisKeys)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	result.TimingBreakdown.DecodeKeysMs = msSince(decodeStart)
+
+	inferStart := time.Now()
+	var scoreCT *rlwe.Ciphertext
+	switch {
+	case len(req.EncryptedVector) > 0:
+		scoreCT, err = scorePacked(evaluator, params, scorer, req.EncryptedVector, req.NumApplicants)
+	case len(req.EncryptedFeatures) > 0:
+		scoreCT, err = scoreSingle(evaluator, params, scorer, req.EncryptedFeatures)
+	default:
+		err = fmt.Errorf("request carries neither encrypted_features nor encrypted_vector")
+	}
+	result.TimingBreakdown.InferenceMs = msSince(inferStart)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+
+	scoreBytes, err := scoreCT.MarshalBinary()
+	if err != nil {
+		result.Error = fmt.Sprintf("marshal score: %v", err)
+		return
+	}
+	result.EncryptedScore = scoreBytes
+}
+
+// buildEvaluator unmarshals rlkBytes/galoisKeyBytes and builds the
+// evaluator they back, the same evaluation-key set
+// rlwe.NewMemEvaluationKeySet expects.
+func buildEvaluator(params ckks.Parameters, rlkBytes []byte, galoisKeyBytes [][]byte) (*ckks.Evaluator, error) {
+	rlk := new(rlwe.RelinearizationKey)
+	if err := rlk.UnmarshalBinary(rlkBytes); err != nil {
+		return nil, fmt.Errorf("unmarshal rlk: %v", err)
+	}
+
+	rotk := make([]*rlwe.GaloisKey, len(galoisKeyBytes))
+	for i, b := range galoisKeyBytes {
+		gk := new(rlwe.GaloisKey)
+		if err := gk.UnmarshalBinary(b); err != nil {
+			return nil, fmt.Errorf("unmarshal galois key %d: %v", i, err)
+		}
+		rotk[i] = gk
+	}
+
+	evk := rlwe.NewMemEvaluationKeySet(rlk, rotk...)
+	return ckks.NewEvaluator(params, evk), nil
+}
+
+// scoreSingle scores one applicant's feature vector, mirroring
+// internal/server's StreamCoordinator.score.
+func scoreSingle(evaluator *ckks.Evaluator, params ckks.Parameters, scorer *app.CreditScorer, ctBytes []byte) (*rlwe.Ciphertext, error) {
+	ct := new(rlwe.Ciphertext)
+	if err := ct.UnmarshalBinary(ctBytes); err != nil {
+		return nil, fmt.Errorf("unmarshal features: %v", err)
+	}
+
+	weights := scorer.Weights()
+	encoder := ckks.NewEncoder(params)
+
+	weightValues := make([]complex128, params.MaxSlots())
+	for i, w := range weights {
+		weightValues[i] = complex(w, 0)
+	}
+	weightsPt := ckks.NewPlaintext(params, ct.Level())
+	if err := encoder.Encode(weightValues, weightsPt); err != nil {
+		return nil, fmt.Errorf("encode weights: %v", err)
+	}
+
+	result, err := app.InnerProduct(evaluator, ct, weightsPt, len(weights))
+	if err != nil {
+		return nil, fmt.Errorf("inner product: %v", err)
+	}
+
+	return addBias(evaluator, encoder, params, result, scorer.Bias(), 1)
+}
+
+// scorePacked scores a feature-major packed matrix (one ciphertext per
+// feature, each packing numApplicants applicants across its slots), the
+// same layout app.InferCreditScoreBatch expects, but against evaluator
+// instead of an InferenceEngine built from a server-held secret key.
+func scorePacked(evaluator *ckks.Evaluator, params ckks.Parameters, scorer *app.CreditScorer, vectorBytes [][]byte, numApplicants int) (*rlwe.Ciphertext, error) {
+	weights := scorer.Weights()
+	if len(vectorBytes) != len(weights) {
+		return nil, fmt.Errorf("packed feature count mismatch: expected %d, got %d", len(weights), len(vectorBytes))
+	}
+	if numApplicants <= 0 || numApplicants > params.MaxSlots() {
+		return nil, fmt.Errorf("numApplicants %d out of range (1..%d)", numApplicants, params.MaxSlots())
+	}
+
+	matrix := make([]*rlwe.Ciphertext, len(vectorBytes))
+	for i, b := range vectorBytes {
+		ct := new(rlwe.Ciphertext)
+		if err := ct.UnmarshalBinary(b); err != nil {
+			return nil, fmt.Errorf("unmarshal feature %d: %v", i, err)
+		}
+		matrix[i] = ct
+	}
+
+	encoder := ckks.NewEncoder(params)
+	packConstant := func(v float64, level int) (*rlwe.Plaintext, error) {
+		values := make([]complex128, params.MaxSlots())
+		for i := 0; i < numApplicants; i++ {
+			values[i] = complex(v, 0)
+		}
+		pt := ckks.NewPlaintext(params, level)
+		return pt, encoder.Encode(values, pt)
+	}
+
+	wPt0, err := packConstant(weights[0], matrix[0].Level())
+	if err != nil {
+		return nil, fmt.Errorf("pack weight 0: %v", err)
+	}
+	result, err := evaluator.MulNew(matrix[0], wPt0)
+	if err != nil {
+		return nil, fmt.Errorf("mul feature 0: %v", err)
+	}
+	if err := evaluator.Rescale(result, result); err != nil {
+		return nil, fmt.Errorf("rescale feature 0: %v", err)
+	}
+
+	for i := 1; i < len(matrix); i++ {
+		wPt, err := packConstant(weights[i], matrix[i].Level())
+		if err != nil {
+			return nil, fmt.Errorf("pack weight %d: %v", i, err)
+		}
+		weighted, err := evaluator.MulNew(matrix[i], wPt)
+		if err != nil {
+			return nil, fmt.Errorf("mul feature %d: %v", i, err)
+		}
+		if err := evaluator.Rescale(weighted, weighted); err != nil {
+			return nil, fmt.Errorf("rescale feature %d: %v", i, err)
+		}
+		if err := evaluator.Add(result, weighted, result); err != nil {
+			return nil, fmt.Errorf("add feature %d: %v", i, err)
+		}
+	}
+
+	return addBias(evaluator, encoder, params, result, scorer.Bias(), numApplicants)
+}
+
+// addBias adds bias to every one of the first numApplicants slots of
+// result.
+func addBias(evaluator *ckks.Evaluator, encoder *ckks.Encoder, params ckks.Parameters, result *rlwe.Ciphertext, bias float64, numApplicants int) (*rlwe.Ciphertext, error) {
+	biasValues := make([]complex128, params.MaxSlots())
+	for i := 0; i < numApplicants; i++ {
+		biasValues[i] = complex(bias, 0)
+	}
+	biasPt := ckks.NewPlaintext(params, result.Level())
+	if err := encoder.Encode(biasValues, biasPt); err != nil {
+		return nil, fmt.Errorf("encode bias: %v", err)
+	}
+	if err := evaluator.Add(result, biasPt, result); err != nil {
+		return nil, fmt.Errorf("add bias: %v", err)
+	}
+	return result, nil
+}
+
+func msSince(start time.Time) float64 {
+	return float64(time.Since(start).Microseconds()) / 1000.0
+}
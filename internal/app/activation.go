@@ -0,0 +1,97 @@
+package app
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// sigmoidMinimaxCoeffs holds the same degree-3/7/15 minimax fits of σ(x) on
+// [-8, 8] as backend/sigmoid's MinimaxApprox/PSApprox tables (ascending
+// monomial coefficients, only odd powers non-zero). internal/app has no
+// import path back to the backend module, so the tables are duplicated here
+// the same way wasm/polynomial.go duplicates them rather than importing
+// across modules.
+var sigmoidMinimaxCoeffs = map[int][]float64{
+	3: {0.5, 0.2159198, 0.0, -0.0082176},
+	7: {0.5, 0.2471169, 0.0, -0.0195740, 0.0, 0.0015314, 0.0, -0.0000451},
+	15: {
+		0.5, 0.2494954, 0.0, -0.0204708, 0.0, 0.0012720, 0.0, -0.0000505,
+		0.0, 0.0000012, 0.0, -0.00000002, 0.0, 0.0, 0.0, 0.0,
+	},
+}
+
+// ApproxRange bounds the input domain EncryptedSigmoid's polynomial was fit
+// on and picks which precomputed minimax table to evaluate via Degree: a
+// lower degree costs less multiplicative depth at the price of accuracy,
+// the same dial backend/sigmoid's degree-3/5/7 tables expose. Degree must be
+// one of the keys in sigmoidMinimaxCoeffs (3, 7, 15).
+type ApproxRange struct {
+	Lower, Upper float64
+	Degree       int
+}
+
+// DefaultSigmoidRange is the degree-7 minimax fit on [-8, 8] this request
+// asks for: accurate enough for a credit-scoring logit while costing three
+// fewer multiplications than the degree-15 table.
+var DefaultSigmoidRange = ApproxRange{Lower: -8, Upper: 8, Degree: 7}
+
+// SupportedSigmoidDegrees returns the minimax table degrees EncryptedSigmoid
+// can evaluate (the keys of sigmoidMinimaxCoeffs), ascending, so a caller
+// building a ServerInfo response doesn't hardcode the table in two places.
+func SupportedSigmoidDegrees() []int {
+	degrees := make([]int, 0, len(sigmoidMinimaxCoeffs))
+	for d := range sigmoidMinimaxCoeffs {
+		degrees = append(degrees, d)
+	}
+	sort.Ints(degrees)
+	return degrees
+}
+
+// RequiredDepth returns the multiplicative depth EncryptedSigmoid needs for
+// r's table, matching backend/sigmoid.PSApprox.RequiredDepth's
+// ceil(log2(degree))+1 formula for Lattigo's Paterson-Stockmeyer evaluator.
+func (r ApproxRange) RequiredDepth() int {
+	depth := 1
+	for d := 1; d < r.Degree; d *= 2 {
+		depth++
+	}
+	return depth
+}
+
+// EncryptedSigmoid evaluates a minimax polynomial approximation of σ(x),
+// selected by approxRange, directly on ct using Lattigo's
+// polynomial.Evaluator: for a degree-7+ polynomial it evaluates via
+// Paterson-Stockmeyer rather than Horner's method, the same algorithm
+// backend/sigmoid.PSApprox hand-rolls, but driven through Lattigo's own
+// scale/level-managed evaluator instead. ct must have at least
+// approxRange.RequiredDepth() levels remaining.
+func EncryptedSigmoid(evaluator *ckks.Evaluator, params ckks.Parameters, ct *rlwe.Ciphertext, approxRange ApproxRange) (*rlwe.Ciphertext, error) {
+	coeffs, ok := sigmoidMinimaxCoeffs[approxRange.Degree]
+	if !ok {
+		return nil, fmt.Errorf("EncryptedSigmoid: unsupported degree %d, expected 3, 7, or 15", approxRange.Degree)
+	}
+
+	if needed := approxRange.RequiredDepth(); ct.Level() < needed {
+		return nil, fmt.Errorf("EncryptedSigmoid: ciphertext level %d below required depth %d", ct.Level(), needed)
+	}
+
+	prec := uint(128)
+	bignumCoeffs := make([]*big.Float, len(coeffs))
+	for i, c := range coeffs {
+		bignumCoeffs[i] = bignum.NewFloat(c, prec)
+	}
+	poly := bignum.NewPolynomial(bignum.Monomial, bignumCoeffs, nil)
+
+	polyEval := polynomial.NewEvaluator(params, evaluator)
+	result, err := polyEval.Evaluate(ct, polynomial.NewPolynomial(poly), params.DefaultScale())
+	if err != nil {
+		return nil, fmt.Errorf("EncryptedSigmoid polynomial evaluation failed: %v", err)
+	}
+	return result, nil
+}
@@ -3,14 +3,54 @@ package app
 import (
 	"fmt"
 	"log"
+
+	"ckks-credit/internal/crypto"
+)
+
+// Mode selects whether Application's key material is held by a single
+// party (the default, matching every existing deployment) or jointly by
+// N institutions under a t-of-n threshold ceremony
+// (crypto.ThresholdKeyGen) — federated credit scoring, where no single
+// bank holds a secret key that decrypts another's applicants on its own.
+type Mode int
+
+const (
+	// ModeSingleParty is NewApplication's default: whichever process holds
+	// the secret key (typically a client, per this repo's zero-trust
+	// design) holds all of it.
+	ModeSingleParty Mode = iota
+	// ModeThreshold requires a Config's N/Threshold to build a
+	// crypto.ThresholdKeyGen ceremony coordinator instead.
+	ModeThreshold
 )
 
+// Config configures NewApplicationWithConfig. N and Threshold are only
+// read when Mode is ModeThreshold.
+type Config struct {
+	Mode      Mode
+	N         int
+	Threshold int
+}
+
 type Application struct {
 	CreditService *CreditScoringService
+
+	// Mode records which key-holding model this Application was built
+	// under. ThresholdKeyGen is nil unless Mode is ModeThreshold.
+	Mode            Mode
+	ThresholdKeyGen *crypto.ThresholdKeyGen
 }
 
-// NewApplication 애플리케이션 초기화
+// NewApplication 애플리케이션 초기화 — ModeSingleParty 고정.
 func NewApplication() (*Application, error) {
+	return NewApplicationWithConfig(Config{Mode: ModeSingleParty})
+}
+
+// NewApplicationWithConfig initializes an Application under cfg.Mode. In
+// ModeThreshold it also builds the t-of-n ThresholdKeyGen coordinator
+// institutions use to run the key ceremony and later partial decryptions
+// — see internal/crypto's ThresholdKeyGen and PartialDecryptor.
+func NewApplicationWithConfig(cfg Config) (*Application, error) {
 	log.Println("Application initialized...")
 
 	// 신용점수 서비스 초기화
@@ -21,6 +61,16 @@ func NewApplication() (*Application, error) {
 
 	app := &Application{
 		CreditService: creditService,
+		Mode:          cfg.Mode,
+	}
+
+	if cfg.Mode == ModeThreshold {
+		tkg, err := crypto.NewThresholdKeyGen(creditService.Params(), cfg.N, cfg.Threshold)
+		if err != nil {
+			return nil, fmt.Errorf("Threshold Key Ceremony Initialization Failed!: %v", err)
+		}
+		app.ThresholdKeyGen = tkg
+		log.Printf("Application running in threshold mode (%d-of-%d)", cfg.Threshold, cfg.N)
 	}
 
 	log.Println("Application initialization succeeded")
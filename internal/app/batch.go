@@ -0,0 +1,134 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// BatchEncoder packs one value per applicant into the slots of a single
+// ciphertext so a feature column (not a single scalar) can be scored in one
+// homomorphic pass. Slot 0-only encoding wastes the ~MaxSlots() SIMD lanes
+// CKKS gives us; BatchEncoder is how InferCreditScoreBatch gets them back.
+type BatchEncoder struct {
+	params  ckks.Parameters
+	encoder *ckks.Encoder
+}
+
+// NewBatchEncoder creates a batch encoder bound to params.
+func NewBatchEncoder(params ckks.Parameters) *BatchEncoder {
+	return &BatchEncoder{
+		params:  params,
+		encoder: ckks.NewEncoder(params),
+	}
+}
+
+// MaxBatchSize 는 한 번에 패킹 가능한 최대 지원자 수를 반환한다.
+// CKKS packs complex128 slots, so the usable slot count is MaxSlots/2 worth
+// of independent real values once conjugate symmetry is accounted for on the
+// client side; we keep it simple and expose MaxSlots directly since the
+// values we pack are already real-valued.
+func (b *BatchEncoder) MaxBatchSize() int {
+	return b.params.MaxSlots()
+}
+
+// Pack encodes one feature value per applicant into a single plaintext,
+// one applicant per slot. len(values) must not exceed MaxBatchSize().
+func (b *BatchEncoder) Pack(values []float64, level int) (*rlwe.Plaintext, error) {
+	if len(values) > b.MaxBatchSize() {
+		return nil, fmt.Errorf("batch pack failed: %d applicants exceeds max batch size %d", len(values), b.MaxBatchSize())
+	}
+
+	slots := make([]complex128, b.params.MaxSlots())
+	for i, v := range values {
+		slots[i] = complex(v, 0)
+	}
+
+	pt := ckks.NewPlaintext(b.params, level)
+	if err := b.encoder.Encode(slots, pt); err != nil {
+		return nil, fmt.Errorf("batch pack encoding failed: %v", err)
+	}
+	return pt, nil
+}
+
+// Unpack decrypts-decodes is left to the caller (via crypto.Decryptor); Unpack
+// just trims a fully-decoded slot slice back down to numApplicants scores,
+// discarding the unused trailing slots.
+func (b *BatchEncoder) Unpack(decoded []complex128, numApplicants int) ([]float64, error) {
+	if numApplicants > len(decoded) {
+		return nil, fmt.Errorf("batch unpack failed: requested %d applicants but only %d slots decoded", numApplicants, len(decoded))
+	}
+
+	scores := make([]float64, numApplicants)
+	for i := 0; i < numApplicants; i++ {
+		scores[i] = real(decoded[i])
+	}
+	return scores, nil
+}
+
+// InferCreditScoreBatch scores numApplicants at once. encryptedFeatureMatrix
+// has one ciphertext per model feature, and each ciphertext packs that
+// feature's value for every applicant across its slots (applicant i in slot
+// i). A single dot-product + sigmoid therefore produces numApplicants scores
+// in the time a single-slot InferCreditScore produces one.
+func (ie *InferenceEngine) InferCreditScoreBatch(encryptedFeatureMatrix []*rlwe.Ciphertext, numApplicants int) (*rlwe.Ciphertext, error) {
+	if len(encryptedFeatureMatrix) != len(ie.scorer.weights) {
+		return nil, fmt.Errorf("batch feature 개수 불일치: expected %d, got %d", len(ie.scorer.weights), len(encryptedFeatureMatrix))
+	}
+
+	batch := NewBatchEncoder(ie.params)
+	if numApplicants <= 0 || numApplicants > batch.MaxBatchSize() {
+		return nil, fmt.Errorf("batch inference failed: numApplicants %d out of range (1..%d)", numApplicants, batch.MaxBatchSize())
+	}
+
+	weightPt, err := batch.Pack(repeat(ie.scorer.weights[0], numApplicants), encryptedFeatureMatrix[0].Level())
+	if err != nil {
+		return nil, fmt.Errorf("batch weight 0 pack failed: %v", err)
+	}
+
+	result, err := ie.evaluator.MulNew(encryptedFeatureMatrix[0], weightPt)
+	if err != nil {
+		return nil, fmt.Errorf("batch mul (feature * weight) 0 failed: %v", err)
+	}
+	if err := ie.evaluator.Rescale(result, result); err != nil {
+		return nil, fmt.Errorf("batch rescale 0 failed: %v", err)
+	}
+
+	for i := 1; i < len(encryptedFeatureMatrix); i++ {
+		wPt, err := batch.Pack(repeat(ie.scorer.weights[i], numApplicants), encryptedFeatureMatrix[i].Level())
+		if err != nil {
+			return nil, fmt.Errorf("batch weight %d pack failed: %v", i, err)
+		}
+
+		weighted, err := ie.evaluator.MulNew(encryptedFeatureMatrix[i], wPt)
+		if err != nil {
+			return nil, fmt.Errorf("batch mul (feature * weight) %d failed: %v", i, err)
+		}
+		if err := ie.evaluator.Rescale(weighted, weighted); err != nil {
+			return nil, fmt.Errorf("batch rescale %d failed: %v", i, err)
+		}
+
+		if err := ie.evaluator.Add(result, weighted, result); err != nil {
+			return nil, fmt.Errorf("batch add %d failed: %v", i, err)
+		}
+	}
+
+	biasPt, err := batch.Pack(repeat(ie.scorer.bias, numApplicants), result.Level())
+	if err != nil {
+		return nil, fmt.Errorf("batch bias pack failed: %v", err)
+	}
+	if err := ie.evaluator.Add(result, biasPt, result); err != nil {
+		return nil, fmt.Errorf("batch bias add failed: %v", err)
+	}
+
+	return result, nil
+}
+
+func repeat(v float64, n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
@@ -0,0 +1,221 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// BatchScorer packs several applicants' feature vectors into one
+// ciphertext's slots instead of BatchEncoder's one-ciphertext-per-feature
+// layout: applicant b's numFeatures values occupy the contiguous block
+// [b*numFeatures, (b+1)*numFeatures), so ApplicantsPerCiphertext() of them
+// are weighted and reduced by a single Mul + Rescale + block rotate-and-sum
+// pass, amortizing that pass across every applicant in the ciphertext
+// instead of paying it once per feature.
+type BatchScorer struct {
+	params      ckks.Parameters
+	encoder     *ckks.Encoder
+	encryptor   *rlwe.Encryptor
+	evaluator   *ckks.Evaluator
+	scorer      *CreditScorer
+	numFeatures int
+}
+
+// NewBatchScorer builds a block-packed batch scorer bound to scorer's
+// weights and bias. sk generates both the encryptor and the Galois keys the
+// block rotate-and-sum tree needs (rotations 1, 2, 4, ... up to
+// nextPowerOfTwo(numFeatures)/2, the same step sequence InnerProduct uses
+// for a single applicant).
+func NewBatchScorer(params ckks.Parameters, scorer *CreditScorer, sk *rlwe.SecretKey) (*BatchScorer, error) {
+	numFeatures := len(scorer.weights)
+	if numFeatures == 0 {
+		return nil, fmt.Errorf("BatchScorer: scorer has no weights")
+	}
+
+	encoder := ckks.NewEncoder(params)
+	kgen := ckks.NewKeyGenerator(params)
+
+	galEls := make([]uint64, 0)
+	for step := 1; step < nextPowerOfTwo(numFeatures); step *= 2 {
+		galEls = append(galEls, params.GaloisElement(step))
+	}
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+	gks := kgen.GenGaloisKeysNew(galEls, sk)
+	evk := rlwe.NewMemEvaluationKeySet(rlk, gks...)
+
+	return &BatchScorer{
+		params:      params,
+		encoder:     encoder,
+		encryptor:   rlwe.NewEncryptor(params, sk),
+		evaluator:   ckks.NewEvaluator(params, evk),
+		scorer:      scorer,
+		numFeatures: numFeatures,
+	}, nil
+}
+
+// ApplicantsPerCiphertext is how many numFeatures-wide applicant blocks fit
+// in a single ciphertext's MaxSlots() slots.
+func (bs *BatchScorer) ApplicantsPerCiphertext() int {
+	return bs.params.MaxSlots() / bs.numFeatures
+}
+
+// Evaluator returns the ckks.Evaluator built from sk's relinearization and
+// rotation keys, so callers composing additional homomorphic stages after
+// Score (e.g. pkg/batch's slotwise sigmoid activation) can reuse the same
+// evaluation keys instead of regenerating them.
+func (bs *BatchScorer) Evaluator() *ckks.Evaluator {
+	return bs.evaluator
+}
+
+// Score encrypts and scores applicants (each a numFeatures-length feature
+// vector) in chunks of ApplicantsPerCiphertext(), returning one ciphertext
+// per chunk: block b of the chunk's ciphertext holds applicant b's score
+// (bias already added) replicated across all numFeatures slots of that
+// block, courtesy of the rotate-and-sum tree.
+func (bs *BatchScorer) Score(applicants [][]float64) ([]*rlwe.Ciphertext, error) {
+	perCT := bs.ApplicantsPerCiphertext()
+	if perCT == 0 {
+		return nil, fmt.Errorf("BatchScorer: %d features exceed MaxSlots %d", bs.numFeatures, bs.params.MaxSlots())
+	}
+
+	results := make([]*rlwe.Ciphertext, 0, (len(applicants)+perCT-1)/perCT)
+	for start := 0; start < len(applicants); start += perCT {
+		end := start + perCT
+		if end > len(applicants) {
+			end = len(applicants)
+		}
+
+		ct, err := bs.scoreChunk(applicants[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("BatchScorer: chunk [%d:%d) failed: %v", start, end, err)
+		}
+		results = append(results, ct)
+	}
+	return results, nil
+}
+
+// scoreChunk scores a single chunk of at most ApplicantsPerCiphertext()
+// applicants: pack, encrypt, one Mul against the tiled weights, Rescale,
+// reduce each block with innerSumBlock, then add the tiled bias.
+func (bs *BatchScorer) scoreChunk(chunk [][]float64) (*rlwe.Ciphertext, error) {
+	for i, features := range chunk {
+		if len(features) != bs.numFeatures {
+			return nil, fmt.Errorf("applicant %d has %d features, want %d", i, len(features), bs.numFeatures)
+		}
+	}
+
+	featurePt := ckks.NewPlaintext(bs.params, bs.params.MaxLevel())
+	if err := bs.encoder.Encode(bs.tileFeatures(chunk), featurePt); err != nil {
+		return nil, fmt.Errorf("feature encode failed: %v", err)
+	}
+	ct, err := bs.encryptor.EncryptNew(featurePt)
+	if err != nil {
+		return nil, fmt.Errorf("feature encrypt failed: %v", err)
+	}
+
+	weightPt := ckks.NewPlaintext(bs.params, ct.Level())
+	if err := bs.encoder.Encode(bs.tileConstant(len(chunk), bs.scorer.weights), weightPt); err != nil {
+		return nil, fmt.Errorf("weight encode failed: %v", err)
+	}
+
+	weighted, err := bs.evaluator.MulNew(ct, weightPt)
+	if err != nil {
+		return nil, fmt.Errorf("weighted mul failed: %v", err)
+	}
+	if err := bs.evaluator.Rescale(weighted, weighted); err != nil {
+		return nil, fmt.Errorf("weighted rescale failed: %v", err)
+	}
+
+	summed, err := innerSumBlock(bs.params, bs.evaluator, weighted, bs.numFeatures, len(chunk))
+	if err != nil {
+		return nil, fmt.Errorf("block inner-sum failed: %v", err)
+	}
+
+	biasValues := make([]float64, bs.numFeatures)
+	for j := range biasValues {
+		biasValues[j] = bs.scorer.bias
+	}
+	biasPt := ckks.NewPlaintext(bs.params, summed.Level())
+	if err := bs.encoder.Encode(bs.tileConstant(len(chunk), biasValues), biasPt); err != nil {
+		return nil, fmt.Errorf("bias encode failed: %v", err)
+	}
+	if err := bs.evaluator.Add(summed, biasPt, summed); err != nil {
+		return nil, fmt.Errorf("bias add failed: %v", err)
+	}
+
+	return summed, nil
+}
+
+// tileFeatures lays each applicant's feature vector into its own
+// numFeatures-wide block of a MaxSlots-length row.
+func (bs *BatchScorer) tileFeatures(chunk [][]float64) []float64 {
+	slots := make([]float64, bs.params.MaxSlots())
+	for b, features := range chunk {
+		copy(slots[b*bs.numFeatures:], features)
+	}
+	return slots
+}
+
+// tileConstant repeats the same numFeatures-length block numBlocks times
+// across a MaxSlots-length row, e.g. so every applicant in the chunk is
+// multiplied by the same weight vector or has the same bias added.
+func (bs *BatchScorer) tileConstant(numBlocks int, block []float64) []float64 {
+	slots := make([]float64, bs.params.MaxSlots())
+	for b := 0; b < numBlocks; b++ {
+		copy(slots[b*bs.numFeatures:], block)
+	}
+	return slots
+}
+
+// innerSumBlock reduces numBlocks independent numFeatures-wide blocks packed
+// into ct down to their per-block sums, via a power-of-two rotate-mask-add
+// tree masked at each step so a block's sum never wraps in its neighbor's
+// values across the block boundary. It is the batched counterpart to
+// InnerProduct's single-applicant rotate-and-add tree.
+func innerSumBlock(params ckks.Parameters, evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, numFeatures, numBlocks int) (*rlwe.Ciphertext, error) {
+	sum := ct.CopyNew()
+	for step := 1; step < nextPowerOfTwo(numFeatures); step *= 2 {
+		rotated, err := evaluator.RotateNew(sum, step)
+		if err != nil {
+			return nil, fmt.Errorf("innerSumBlock Rotate(%d) failed: %v", step, err)
+		}
+
+		mask, err := blockBoundaryMask(params, step, numFeatures, numBlocks, rotated.Level())
+		if err != nil {
+			return nil, err
+		}
+		masked, err := evaluator.MulNew(rotated, mask)
+		if err != nil {
+			return nil, fmt.Errorf("innerSumBlock mask mul(%d) failed: %v", step, err)
+		}
+		if err := evaluator.Rescale(masked, masked); err != nil {
+			return nil, fmt.Errorf("innerSumBlock mask rescale(%d) failed: %v", step, err)
+		}
+
+		if err := evaluator.Add(sum, masked, sum); err != nil {
+			return nil, fmt.Errorf("innerSumBlock Add(rotate %d) failed: %v", step, err)
+		}
+	}
+	return sum, nil
+}
+
+// blockBoundaryMask is 1 in every slot whose position within its
+// numFeatures-wide block is less than numFeatures-step, and 0 elsewhere,
+// zeroing out the block-boundary contribution a rotate-by-step would
+// otherwise wrap in from a neighboring applicant's block.
+func blockBoundaryMask(params ckks.Parameters, step, numFeatures, numBlocks, level int) (*rlwe.Plaintext, error) {
+	encoder := ckks.NewEncoder(params)
+	values := make([]float64, params.MaxSlots())
+	for b := 0; b < numBlocks; b++ {
+		for j := 0; j < numFeatures-step; j++ {
+			values[b*numFeatures+j] = 1
+		}
+	}
+	pt := ckks.NewPlaintext(params, level)
+	if err := encoder.Encode(values, pt); err != nil {
+		return nil, fmt.Errorf("blockBoundaryMask encode failed: %v", err)
+	}
+	return pt, nil
+}
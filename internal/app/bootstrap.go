@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks/bootstrapping"
+)
+
+// LeveledCiphertext wraps a ciphertext together with the level it was
+// produced at, so InferenceEngine.ensureLevel can tell how much
+// multiplicative budget is left without re-deriving it from params each
+// time a sigmoid approximation is picked.
+type LeveledCiphertext struct {
+	CT    *rlwe.Ciphertext
+	Level int
+}
+
+// NewLeveledCiphertext wraps ct, capturing its current level.
+func NewLeveledCiphertext(ct *rlwe.Ciphertext) *LeveledCiphertext {
+	return &LeveledCiphertext{CT: ct, Level: ct.Level()}
+}
+
+// BootstrappableService wraps CreditScoringService with a bootstrapping
+// evaluator so InferenceEngine is no longer capped at MaxLevel=5 — once a
+// ciphertext's remaining level drops below what the selected sigmoid
+// approximation needs, ensureLevel refreshes it via bootstrapping instead of
+// failing with "not enough levels".
+type BootstrappableService struct {
+	params    ckks.Parameters
+	btpParams bootstrapping.Parameters
+	btpEval   *bootstrapping.Evaluator
+	scorer    *CreditScorer
+	inference *InferenceEngine
+}
+
+// NewBootstrappableService builds a CreditScoringService variant backed by a
+// bootstrapping-capable parameter set. sk must come from the same key
+// generator used to produce btpParams' evaluation keys.
+func NewBootstrappableService(params ckks.Parameters, btpParams bootstrapping.Parameters, sk *rlwe.SecretKey) (*BootstrappableService, error) {
+	btpEvk, _, err := btpParams.GenEvaluationKeys(sk)
+	if err != nil {
+		return nil, fmt.Errorf("Bootstrapping Evaluation Key Generation Failed!: %v", err)
+	}
+
+	btpEval, err := bootstrapping.NewEvaluator(btpParams, btpEvk)
+	if err != nil {
+		return nil, fmt.Errorf("Bootstrapping Evaluator Creation Failed!: %v", err)
+	}
+
+	scorer, err := NewCreditScorer(params)
+	if err != nil {
+		return nil, fmt.Errorf("CreditScorer Gen Failed!: %v", err)
+	}
+
+	inference, err := NewInferenceEngine(params, scorer, sk)
+	if err != nil {
+		return nil, fmt.Errorf("InferenceEngine Gen Failed!: %v", err)
+	}
+
+	return &BootstrappableService{
+		params:    params,
+		btpParams: btpParams,
+		btpEval:   btpEval,
+		scorer:    scorer,
+		inference: inference,
+	}, nil
+}
+
+// ensureLevel bootstraps ct in place if its remaining level is below needed,
+// and returns the (possibly refreshed) ciphertext. needed is typically the
+// RequiredDepth() of the sigmoid approximation about to be applied.
+func (ie *InferenceEngine) ensureLevel(ct *rlwe.Ciphertext, needed int) (*rlwe.Ciphertext, error) {
+	if ie.btpEval == nil {
+		if ct.Level() < needed {
+			return nil, fmt.Errorf("ensureLevel Failed!: level %d below required %d and no bootstrapping evaluator configured", ct.Level(), needed)
+		}
+		return ct, nil
+	}
+
+	if ct.Level() >= needed {
+		return ct, nil
+	}
+
+	refreshed, err := ie.btpEval.Bootstrap(ct)
+	if err != nil {
+		return nil, fmt.Errorf("Bootstrap Failed!: %v", err)
+	}
+	return refreshed, nil
+}
+
+// InferCreditScore runs the weighted dot-product and bootstraps the result
+// up front if the configured sigmoid approximation needs more depth than the
+// ciphertext has left.
+func (bs *BootstrappableService) InferCreditScore(encryptedFeatures *rlwe.Ciphertext, sigmoidDepth int) (*rlwe.Ciphertext, error) {
+	bs.inference.btpEval = bs.btpEval
+
+	result, err := bs.inference.InferCreditScore(encryptedFeatures)
+	if err != nil {
+		return nil, fmt.Errorf("Bootstrappable InferCreditScore Failed!: %v", err)
+	}
+
+	result, err = bs.inference.ensureLevel(result, sigmoidDepth)
+	if err != nil {
+		return nil, fmt.Errorf("Bootstrappable ensureLevel Failed!: %v", err)
+	}
+
+	return result, nil
+}
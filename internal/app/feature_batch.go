@@ -0,0 +1,119 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// FeatureBatchScorer is BatchEncoder's feature-major layout (one ciphertext
+// per model feature, one applicant per slot) carried all the way through
+// encryption, weighted-sum-plus-bias, sigmoid activation, and decryption,
+// so a single EncryptedSigmoid evaluation amortizes across every applicant
+// packed into the batch instead of paying for it once per applicant the way
+// encrypting one value per Encryptor.EncryptFloat64 call does. It's the
+// feature-major counterpart to BatchScorer's applicant-major block layout —
+// pick FeatureBatchScorer when numFeatures is small enough that
+// MaxSlots()/1 applicants (rather than MaxSlots()/numFeatures) is the
+// binding constraint.
+type FeatureBatchScorer struct {
+	params      ckks.Parameters
+	encoder     *ckks.Encoder
+	encryptor   *rlwe.Encryptor
+	decryptor   *rlwe.Decryptor
+	inference   *InferenceEngine
+	approxRange ApproxRange
+	batch       *BatchEncoder
+}
+
+// NewFeatureBatchScorer builds a FeatureBatchScorer around inference's
+// weights/bias and evaluation keys. sk must be the same secret key
+// inference was built from; approxRange picks EncryptedSigmoid's minimax
+// table (see DefaultSigmoidRange).
+func NewFeatureBatchScorer(params ckks.Parameters, inference *InferenceEngine, sk *rlwe.SecretKey, approxRange ApproxRange) *FeatureBatchScorer {
+	return &FeatureBatchScorer{
+		params:      params,
+		encoder:     ckks.NewEncoder(params),
+		encryptor:   rlwe.NewEncryptor(params, sk),
+		decryptor:   rlwe.NewDecryptor(params, sk),
+		inference:   inference,
+		approxRange: approxRange,
+		batch:       NewBatchEncoder(params),
+	}
+}
+
+// MaxBatchSize is how many applicants fit across a single ciphertext's
+// slots.
+func (s *FeatureBatchScorer) MaxBatchSize() int {
+	return s.batch.MaxBatchSize()
+}
+
+// EncryptBatch transposes applicants (one slice per applicant, each
+// len(weights) features long) into len(weights) ciphertexts — one per
+// feature, applicant i's value in slot i of each — the layout
+// EvaluateBatch's InferCreditScoreBatch call expects. len(applicants) must
+// not exceed MaxBatchSize().
+func (s *FeatureBatchScorer) EncryptBatch(applicants [][]float64) ([]*rlwe.Ciphertext, error) {
+	if len(applicants) == 0 {
+		return nil, fmt.Errorf("FeatureBatchScorer.EncryptBatch: no applicants")
+	}
+	if len(applicants) > s.MaxBatchSize() {
+		return nil, fmt.Errorf("FeatureBatchScorer.EncryptBatch: %d applicants exceeds max batch size %d", len(applicants), s.MaxBatchSize())
+	}
+
+	numFeatures := len(s.inference.scorer.weights)
+	cts := make([]*rlwe.Ciphertext, numFeatures)
+	for f := 0; f < numFeatures; f++ {
+		column := make([]float64, len(applicants))
+		for i, a := range applicants {
+			if len(a) != numFeatures {
+				return nil, fmt.Errorf("FeatureBatchScorer.EncryptBatch: applicant %d has %d features, want %d", i, len(a), numFeatures)
+			}
+			column[i] = a[f]
+		}
+
+		pt, err := s.batch.Pack(column, s.params.MaxLevel())
+		if err != nil {
+			return nil, fmt.Errorf("FeatureBatchScorer.EncryptBatch: pack feature %d: %v", f, err)
+		}
+		ct, err := s.encryptor.EncryptNew(pt)
+		if err != nil {
+			return nil, fmt.Errorf("FeatureBatchScorer.EncryptBatch: encrypt feature %d: %v", f, err)
+		}
+		cts[f] = ct
+	}
+	return cts, nil
+}
+
+// EvaluateBatch computes Σ w_i·cts[i] + bias across numApplicants applicants
+// packed into cts' slots, then applies EncryptedSigmoid's polynomial exactly
+// once — one ciphertext-level sigmoid evaluation scores the whole batch,
+// not one per applicant.
+func (s *FeatureBatchScorer) EvaluateBatch(cts []*rlwe.Ciphertext, numApplicants int) (*rlwe.Ciphertext, error) {
+	logit, err := s.inference.InferCreditScoreBatch(cts, numApplicants)
+	if err != nil {
+		return nil, fmt.Errorf("FeatureBatchScorer.EvaluateBatch: weighted sum: %v", err)
+	}
+
+	score, err := EncryptedSigmoid(s.inference.evaluator, s.params, logit, s.approxRange)
+	if err != nil {
+		return nil, fmt.Errorf("FeatureBatchScorer.EvaluateBatch: sigmoid: %v", err)
+	}
+	return score, nil
+}
+
+// DecryptBatch decrypts result and returns numApplicants scores, one per
+// applicant slot, in the order EncryptBatch packed them.
+func (s *FeatureBatchScorer) DecryptBatch(result *rlwe.Ciphertext, numApplicants int) ([]float64, error) {
+	if numApplicants > s.params.MaxSlots() {
+		return nil, fmt.Errorf("FeatureBatchScorer.DecryptBatch: %d applicants exceeds %d slots", numApplicants, s.params.MaxSlots())
+	}
+
+	pt := s.decryptor.DecryptNew(result)
+	decoded := make([]float64, s.params.MaxSlots())
+	if err := s.encoder.Decode(pt, decoded); err != nil {
+		return nil, fmt.Errorf("FeatureBatchScorer.DecryptBatch: decode: %v", err)
+	}
+	return decoded[:numApplicants], nil
+}
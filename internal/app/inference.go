@@ -2,8 +2,10 @@ package app
 
 import (
 	"fmt"
+
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
 	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks/bootstrapping"
 )
 
 type InferenceEngine struct {
@@ -11,11 +13,29 @@ type InferenceEngine struct {
 	encoder   *ckks.Encoder
 	evaluator *ckks.Evaluator
 	scorer    *CreditScorer
+	// btpEval is nil unless the engine is driven through a
+	// BootstrappableService; ensureLevel falls back to an error instead of
+	// bootstrapping when it's unset.
+	btpEval *bootstrapping.Evaluator
 }
 
-func NewInferenceEngine(params ckks.Parameters, scorer *CreditScorer) (*InferenceEngine, error) {
+// NewInferenceEngine builds an evaluator with the Galois keys needed for the
+// power-of-two rotate-and-sum inner product InferCreditScore relies on
+// (rotations 1, 2, 4, ... up to nextPowerOfTwo(len(scorer.weights))/2). sk
+// must be the same secret key used to encrypt the caller's features.
+func NewInferenceEngine(params ckks.Parameters, scorer *CreditScorer, sk *rlwe.SecretKey) (*InferenceEngine, error) {
 	encoder := ckks.NewEncoder(params)
-	evaluator := ckks.NewEvaluator(params, nil)
+	kgen := ckks.NewKeyGenerator(params)
+
+	galEls := make([]uint64, 0)
+	for step := 1; step < nextPowerOfTwo(len(scorer.weights)); step *= 2 {
+		galEls = append(galEls, params.GaloisElement(step))
+	}
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+	gks := kgen.GenGaloisKeysNew(galEls, sk)
+	evk := rlwe.NewMemEvaluationKeySet(rlk, gks...)
+
+	evaluator := ckks.NewEvaluator(params, evk)
 
 	return &InferenceEngine{
 		params:    params,
@@ -25,43 +45,72 @@ func NewInferenceEngine(params ckks.Parameters, scorer *CreditScorer) (*Inferenc
 	}, nil
 }
 
-func (ie *InferenceEngine) InferCreditScore(encryptedFeatures []*rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
-	if len(encryptedFeatures) != len(ie.scorer.weights) {
-		return nil, fmt.Errorf("feature 개수 불일치: expected %d, got %d", len(ie.scorer.weights), len(encryptedFeatures))
+// InnerProduct computes sum(ct[i] * wPt[i] for i in 0..numSlots) via one
+// Hadamard product plus a logarithmic rotate-and-add tree: rotations by
+// 1, 2, 4, ... double each step, so summing numSlots values costs
+// log2(nextPowerOfTwo(numSlots)) rotations instead of numSlots-1. Reusable by
+// any linear layer, not just InferCreditScore's weighted sum.
+func InnerProduct(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, wPt *rlwe.Plaintext, numSlots int) (*rlwe.Ciphertext, error) {
+	product, err := evaluator.MulNew(ct, wPt)
+	if err != nil {
+		return nil, fmt.Errorf("InnerProduct Mul Failed!: %v", err)
 	}
-
-	biasPlaintext := ckks.NewPlaintext(ie.params, ie.params.MaxLevel())
-	if err := ie.encoder.Encode([]float64{ie.scorer.bias}, biasPlaintext); err != nil {
-		return nil, fmt.Errorf("Bias Encoding Failed!: %v", err)
+	if err := evaluator.Rescale(product, product); err != nil {
+		return nil, fmt.Errorf("InnerProduct Rescale Failed!: %v", err)
 	}
 
-	result := encryptedFeatures[0].CopyNew()
-	weightsPlaintext := ckks.NewPlaintext(ie.params, ie.params.MaxLevel())
-	if err := ie.encoder.Encode(result, weightsPlaintext); err != nil {
-		return nil, fmt.Errorf("Weights Encoding Failed!: %v", err)
+	sum := product
+	for step := 1; step < nextPowerOfTwo(numSlots); step *= 2 {
+		rotated, err := evaluator.RotateNew(sum, step)
+		if err != nil {
+			return nil, fmt.Errorf("InnerProduct Rotate(%d) Failed!: %v", step, err)
+		}
+		if err := evaluator.Add(sum, rotated, sum); err != nil {
+			return nil, fmt.Errorf("InnerProduct Add(rotate %d) Failed!: %v", step, err)
+		}
 	}
 
-	if err := ie.evaluator.Mul(result, weightsPlaintext, result); err != nil {
-		return nil, fmt.Errorf("Mul (feature * weight) Failed!: %v", err)
+	return sum, nil
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p *= 2
 	}
+	return p
+}
 
-	for i := 1; i < len(encryptedFeatures); i++ {
-		if err := ie.encoder.Encode([]float64{ie.scorer.weights[i]}, weightsPlaintext); err != nil {
-			return nil, fmt.Errorf("Weights %d Encoding Failed!: %v", i, err)
-		}
+// InferCreditScore expects a single ciphertext with one feature per slot
+// (slots 0..len(weights)-1) rather than one ciphertext per feature: a single
+// Mul against the weights plaintext plus InnerProduct's rotate-and-add tree
+// replaces the old per-feature loop, and the bias is added, not multiplied.
+func (ie *InferenceEngine) InferCreditScore(encryptedFeatures *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	numFeatures := len(ie.scorer.weights)
 
-		temp := encryptedFeatures[i].CopyNew()
-		if err := ie.evaluator.Mul(temp, weightsPlaintext, temp); err != nil {
-			return nil, fmt.Errorf("Mul %d (feature * weight) Failed!: %v", i, err)
-		}
+	weightValues := make([]complex128, ie.params.MaxSlots())
+	for i, w := range ie.scorer.weights {
+		weightValues[i] = complex(w, 0)
+	}
+	weightsPlaintext := ckks.NewPlaintext(ie.params, encryptedFeatures.Level())
+	if err := ie.encoder.Encode(weightValues, weightsPlaintext); err != nil {
+		return nil, fmt.Errorf("Weights Encoding Failed!: %v", err)
+	}
 
-		if err := ie.evaluator.Add(result, temp, result); err != nil {
-			return nil, fmt.Errorf("Add %d (feature * weight) Failed!: %v", i, err)
-		}
+	result, err := InnerProduct(ie.evaluator, encryptedFeatures, weightsPlaintext, numFeatures)
+	if err != nil {
+		return nil, fmt.Errorf("InferCreditScore InnerProduct Failed!: %v", err)
+	}
+
+	biasValues := make([]complex128, ie.params.MaxSlots())
+	biasValues[0] = complex(ie.scorer.bias, 0)
+	biasPlaintext := ckks.NewPlaintext(ie.params, result.Level())
+	if err := ie.encoder.Encode(biasValues, biasPlaintext); err != nil {
+		return nil, fmt.Errorf("Bias Encoding Failed!: %v", err)
 	}
 
-	if err := ie.evaluator.Mul(result, biasPlaintext, result); err != nil {
-		return nil, fmt.Errorf("Bias addition Failed!: %v", err)
+	if err := ie.evaluator.Add(result, biasPlaintext, result); err != nil {
+		return nil, fmt.Errorf("Bias Addition Failed!: %v", err)
 	}
 
 	return result, nil
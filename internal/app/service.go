@@ -13,9 +13,12 @@ type CreditScoringService struct {
 }
 
 func NewCreditScoringService() (*CreditScoringService, error) {
-	paramsLit := ckks.ParametersLiternal{
+	paramsLit := ckks.ParametersLiteral{
 		LogN:            12,
-		LogQ:            []int{38, 32},
+		// widened by 3 levels (was {38, 32}) so a weighted sum followed by
+		// EncryptedSigmoid's degree-7 minimax approximation still has levels
+		// left to decode.
+		LogQ:            []int{38, 32, 32, 32, 32},
 		LogP:            []int{39},
 		LogDefaultScale: 32,
 	}
@@ -30,7 +33,13 @@ func NewCreditScoringService() (*CreditScoringService, error) {
 		return nil, fmt.Errorf("CreditScorer Gen Failed!: %v", err)
 	}
 
-	inference, err := NewIn
+	kgen := ckks.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+
+	inference, err := NewInferenceEngine(params, scorer, sk)
+	if err != nil {
+		return nil, fmt.Errorf("Inference Engine Initialization Failed!: %v", err)
+	}
 
 	return &CreditScoringService{
 		params:    params,
@@ -38,3 +47,57 @@ func NewCreditScoringService() (*CreditScoringService, error) {
 		inference: inference,
 	}, nil
 }
+
+// Params returns the CKKS parameters cs scores under, so callers building
+// a threshold key ceremony (crypto.NewThresholdKeyGen) or any other
+// party-side tooling can match them instead of hardcoding a second copy.
+func (cs *CreditScoringService) Params() ckks.Parameters {
+	return cs.params
+}
+
+// InferCreditScore runs the weighted dot-product inference pipeline on an
+// already-encrypted feature vector. It's the entry point internal/grpcapi
+// calls so the gRPC and REST paths share the exact same scoring logic.
+func (cs *CreditScoringService) InferCreditScore(encryptedFeatures *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	return cs.inference.InferCreditScore(encryptedFeatures)
+}
+
+// InferCreditScorePacked runs InferCreditScoreBatch for numApplicants packed
+// across encryptedFeatureMatrix's slots, the batched counterpart
+// InferencePacked calls alongside InferCreditScore's unary Inference.
+func (cs *CreditScoringService) InferCreditScorePacked(encryptedFeatureMatrix []*rlwe.Ciphertext, numApplicants int) (*rlwe.Ciphertext, error) {
+	return cs.inference.InferCreditScoreBatch(encryptedFeatureMatrix, numApplicants)
+}
+
+// ModelVersion identifies the weights and bias CreditScorer currently
+// serves; ServerInfo reports it so a client can confirm it's scoring
+// against the model it expects instead of assuming out of band.
+const ModelVersion = "credit-scorer-v1"
+
+// ServerInfo describes the CKKS parameters, model, and sigmoid
+// approximations this service scores with, so a client can auto-configure
+// its own encoder/keygen to match instead of hardcoding them out of band.
+type ServerInfo struct {
+	LogN                    int
+	LogQ                    []int
+	LogP                    []int
+	LogDefaultScale         int
+	MaxLevel                int
+	MaxSlots                int
+	ModelVersion            string
+	SupportedSigmoidDegrees []int
+}
+
+// ServerInfo returns cs's ServerInfo.
+func (cs *CreditScoringService) ServerInfo() ServerInfo {
+	return ServerInfo{
+		LogN:                    cs.params.LogN(),
+		LogQ:                    cs.params.LogQ(),
+		LogP:                    cs.params.LogP(),
+		LogDefaultScale:         cs.params.LogDefaultScale(),
+		MaxLevel:                cs.params.MaxLevel(),
+		MaxSlots:                cs.params.MaxSlots(),
+		ModelVersion:            ModelVersion,
+		SupportedSigmoidDegrees: SupportedSigmoidDegrees(),
+	}
+}
@@ -0,0 +1,209 @@
+// diagnostics.go adds a benchmarking and noise-budget diagnostic
+// subsystem: Diagnostics drives a configurable encode/encrypt/eval/decrypt
+// workload against a KeyManager and evaluator, measuring per-stage latency
+// statistics, the evaluated ciphertext's size and remaining multiplicative
+// depth, and precision loss against a known cleartext reference, so
+// operators get calibrated numbers instead of the ad-hoc one-off prints
+// scattered across this repo's E2E harnesses.
+package crypto
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// StageStats summarizes one pipeline stage's latency across a Diagnostics
+// run, in milliseconds.
+type StageStats struct {
+	Mean   float64
+	StdDev float64
+	P95    float64
+	Count  int
+}
+
+// Report is one Diagnostics.Run's output.
+type Report struct {
+	Encode  StageStats
+	Encrypt StageStats
+	Eval    StageStats
+	Decrypt StageStats
+
+	// CiphertextBytes is the last run's evaluated ciphertext's marshalled
+	// size, the number operators compare against each transport's
+	// bytes-on-the-wire cost.
+	CiphertextBytes int
+
+	// RemainingLevels is params.MaxLevel() minus the last run's evaluated
+	// ciphertext's Level(): how much multiplicative depth is left before
+	// the modulus chain is exhausted and bootstrapping (or a fresh
+	// encryption) is required.
+	RemainingLevels int
+
+	// PrecisionLossAvg/PrecisionLossMax are |decoded - cleartext| across
+	// the run's known reference values, an estimate of how much accuracy
+	// the chosen parameters and workload are costing.
+	PrecisionLossAvg float64
+	PrecisionLossMax float64
+}
+
+// Workload is one applicant's known cleartext features: Eval is the
+// homomorphic operation under test (e.g. a weighted sum), and Reference
+// computes the same operation's expected result in cleartext so Run can
+// estimate precision loss.
+type Workload struct {
+	Features  []float64
+	Eval      func(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error)
+	Reference func(features []float64) float64
+}
+
+// Diagnostics runs a configurable workload against km's keys via evaluator
+// and reports calibrated latency, size, and precision numbers.
+type Diagnostics struct {
+	params    ckks.Parameters
+	evaluator *ckks.Evaluator
+	encoder   *ckks.Encoder
+	encryptor *rlwe.Encryptor
+	decryptor *rlwe.Decryptor
+}
+
+// NewDiagnostics builds a Diagnostics that encrypts under km's public key,
+// evaluates with evaluator (which must already be built with whatever
+// relinearization/rotation keys the workload's Eval needs), and decrypts
+// with km's secret key.
+func NewDiagnostics(params ckks.Parameters, km *KeyManager, evaluator *ckks.Evaluator) *Diagnostics {
+	return &Diagnostics{
+		params:    params,
+		evaluator: evaluator,
+		encoder:   ckks.NewEncoder(params),
+		encryptor: rlwe.NewEncryptor(params, km.PublicKey()),
+		decryptor: rlwe.NewDecryptor(params, km.SecretKey()),
+	}
+}
+
+// Run drives each workload through encode/encrypt/eval/decrypt reps times
+// (N applicants x M repetitions), recording one latency sample per stage
+// per iteration, and returns the calibrated statistics plus the last
+// iteration's ciphertext size, remaining depth, and precision loss.
+func (d *Diagnostics) Run(workloads []Workload, reps int) (*Report, error) {
+	if reps <= 0 {
+		reps = 1
+	}
+
+	var encodeSamples, encryptSamples, evalSamples, decryptSamples []float64
+	var precisionLosses []float64
+	var lastResult *rlwe.Ciphertext
+
+	for _, w := range workloads {
+		for r := 0; r < reps; r++ {
+			startEncode := time.Now()
+			pt := ckks.NewPlaintext(d.params, d.params.MaxLevel())
+			if err := d.encoder.Encode(w.Features, pt); err != nil {
+				return nil, fmt.Errorf("diagnostics: encode: %v", err)
+			}
+			encodeSamples = append(encodeSamples, msSince(startEncode))
+
+			startEncrypt := time.Now()
+			ct, err := d.encryptor.EncryptNew(pt)
+			if err != nil {
+				return nil, fmt.Errorf("diagnostics: encrypt: %v", err)
+			}
+			encryptSamples = append(encryptSamples, msSince(startEncrypt))
+
+			startEval := time.Now()
+			result, err := w.Eval(d.evaluator, ct)
+			if err != nil {
+				return nil, fmt.Errorf("diagnostics: eval: %v", err)
+			}
+			evalSamples = append(evalSamples, msSince(startEval))
+
+			startDecrypt := time.Now()
+			decoded := make([]float64, d.params.MaxSlots())
+			resultPt := d.decryptor.DecryptNew(result)
+			if err := d.encoder.Decode(resultPt, decoded); err != nil {
+				return nil, fmt.Errorf("diagnostics: decode: %v", err)
+			}
+			decryptSamples = append(decryptSamples, msSince(startDecrypt))
+
+			if w.Reference != nil {
+				precisionLosses = append(precisionLosses, math.Abs(decoded[0]-w.Reference(w.Features)))
+			}
+			lastResult = result
+		}
+	}
+
+	report := &Report{
+		Encode:  stageStats(encodeSamples),
+		Encrypt: stageStats(encryptSamples),
+		Eval:    stageStats(evalSamples),
+		Decrypt: stageStats(decryptSamples),
+	}
+
+	if lastResult != nil {
+		ctBytes, err := lastResult.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("diagnostics: marshal final ciphertext: %v", err)
+		}
+		report.CiphertextBytes = len(ctBytes)
+		report.RemainingLevels = d.params.MaxLevel() - lastResult.Level()
+	}
+
+	if len(precisionLosses) > 0 {
+		var sum, max float64
+		for _, loss := range precisionLosses {
+			sum += loss
+			if loss > max {
+				max = loss
+			}
+		}
+		report.PrecisionLossAvg = sum / float64(len(precisionLosses))
+		report.PrecisionLossMax = max
+	}
+
+	return report, nil
+}
+
+func msSince(start time.Time) float64 {
+	return float64(time.Since(start).Microseconds()) / 1000.0
+}
+
+// stageStats computes mean, population stddev, and p95 over samples.
+func stageStats(samples []float64) StageStats {
+	if len(samples) == 0 {
+		return StageStats{}
+	}
+
+	var sum float64
+	for _, s := range samples {
+		sum += s
+	}
+	mean := sum / float64(len(samples))
+
+	var variance float64
+	for _, s := range samples {
+		delta := s - mean
+		variance += delta * delta
+	}
+	variance /= float64(len(samples))
+
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	p95Index := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	if p95Index < 0 {
+		p95Index = 0
+	}
+	if p95Index >= len(sorted) {
+		p95Index = len(sorted) - 1
+	}
+
+	return StageStats{
+		Mean:   mean,
+		StdDev: math.Sqrt(variance),
+		P95:    sorted[p95Index],
+		Count:  len(samples),
+	}
+}
@@ -4,15 +4,20 @@ import (
 	"fmt"
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
 	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
-	"golang.org/x/sys/unix"
 )
 
 type KeyManager struct {
-	params ckks.Parameters
-	kgen   *rlwe.KeyGenerator
-	sk     *rlwe.SecretKey
-	pk     *rlwe.PublicKey
-	rlk    *rlwe.RelinearizationKey
+	params  ckks.Parameters
+	kgen    *rlwe.KeyGenerator
+	sk      *rlwe.SecretKey
+	pk      *rlwe.PublicKey
+	rlk     *rlwe.RelinearizationKey
+	rotKeys []*rlwe.GaloisKey
+
+	// skLocked is sk's marshalled bytes, held in an mlock'd buffer so the
+	// secret key material can't be swapped to disk; see SaveToDir,
+	// LoadFromDir and Zeroize in persist.go.
+	skLocked []byte
 }
 
 func NewKeyManager(params ckks.Parameters) (*KeyManager, error) {
@@ -43,7 +48,43 @@ func (km *KeyManager) Key() *rlwe.RelinearizationKey {
 	return km.rlk
 }
 
-func (km *KeyManager) GenerateRotationKeys(rotations []int) (*rlwe.RotationKeySet), error {
-	rotkeys := km.kgen.GenerateRotationKeys(rotations, km.sk)
+// GenerateRotationKeys builds the Galois keys for each requested rotation
+// step (e.g. the power-of-two steps an InnerSum/rotate-and-sum dot product
+// needs) via params.GaloisElement(k), the same key-gen pattern
+// app.NewInferenceEngine uses.
+func (km *KeyManager) GenerateRotationKeys(rotations []int) ([]*rlwe.GaloisKey, error) {
+	galEls := make([]uint64, len(rotations))
+	for i, step := range rotations {
+		galEls[i] = km.params.GaloisElement(step)
+	}
+	km.rotKeys = km.kgen.GenGaloisKeysNew(galEls, km.sk)
+	return km.rotKeys, nil
+}
 
-}
\ No newline at end of file
+// MarshalRotationKeys serializes rotKeys (as returned by GenerateRotationKeys)
+// so a client can ship them alongside the RLK, the same way E2E harnesses
+// already ship a marshalled relinearization key.
+func MarshalRotationKeys(rotKeys []*rlwe.GaloisKey) ([][]byte, error) {
+	out := make([][]byte, len(rotKeys))
+	for i, gk := range rotKeys {
+		b, err := gk.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("marshal rotation key %d: %v", i, err)
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+// UnmarshalRotationKeys is MarshalRotationKeys' inverse.
+func UnmarshalRotationKeys(data [][]byte) ([]*rlwe.GaloisKey, error) {
+	out := make([]*rlwe.GaloisKey, len(data))
+	for i, b := range data {
+		gk := new(rlwe.GaloisKey)
+		if err := gk.UnmarshalBinary(b); err != nil {
+			return nil, fmt.Errorf("unmarshal rotation key %d: %v", i, err)
+		}
+		out[i] = gk
+	}
+	return out, nil
+}
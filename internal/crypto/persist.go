@@ -0,0 +1,216 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	argon2Time    = 1
+	argon2Memory  = 64 * 1024 // KiB
+	argon2Threads = 4
+
+	skSaltSize = 16
+
+	pkFileName  = "pk.bin"
+	rlkFileName = "rlk.bin"
+	skFileName  = "sk.enc"
+)
+
+// deriveAEADKey stretches passphrase into a chacha20poly1305 key via
+// argon2id, keyed by salt so the same passphrase never derives the same
+// key twice across key sets.
+func deriveAEADKey(passphrase, salt []byte) []byte {
+	return argon2.IDKey(passphrase, salt, argon2Time, argon2Memory, argon2Threads, chacha20poly1305.KeySize)
+}
+
+// SaveToDir serializes sk, pk, rlk and any rotation keys generated via
+// GenerateRotationKeys to dir: pk and rlk in the clear (they're not
+// secret), sk encrypted with a chacha20poly1305 AEAD keyed by passphrase
+// via argon2id. The encrypted sk file is chmod'd 0600 so only the owning
+// user can read it even if dir itself is shared.
+func (km *KeyManager) SaveToDir(dir string, passphrase []byte) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("SaveToDir: failed to create %s: %v", dir, err)
+	}
+
+	pkBytes, err := km.pk.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("SaveToDir: marshal pk: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, pkFileName), pkBytes, 0644); err != nil {
+		return fmt.Errorf("SaveToDir: write pk: %v", err)
+	}
+
+	rlkBytes, err := km.rlk.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("SaveToDir: marshal rlk: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, rlkFileName), rlkBytes, 0644); err != nil {
+		return fmt.Errorf("SaveToDir: write rlk: %v", err)
+	}
+
+	if len(km.rotKeys) > 0 {
+		rotBytes, err := MarshalRotationKeys(km.rotKeys)
+		if err != nil {
+			return fmt.Errorf("SaveToDir: marshal rotation keys: %v", err)
+		}
+		for i, b := range rotBytes {
+			name := filepath.Join(dir, fmt.Sprintf("rot%d.bin", i))
+			if err := os.WriteFile(name, b, 0644); err != nil {
+				return fmt.Errorf("SaveToDir: write %s: %v", name, err)
+			}
+		}
+	}
+
+	skBytes, err := km.sk.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("SaveToDir: marshal sk: %v", err)
+	}
+
+	salt := make([]byte, skSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("SaveToDir: generate salt: %v", err)
+	}
+	aead, err := chacha20poly1305.New(deriveAEADKey(passphrase, salt))
+	if err != nil {
+		return fmt.Errorf("SaveToDir: build AEAD: %v", err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("SaveToDir: generate nonce: %v", err)
+	}
+
+	sealed := aead.Seal(nil, nonce, skBytes, nil)
+	out := append(append(salt, nonce...), sealed...)
+
+	skPath := filepath.Join(dir, skFileName)
+	if err := os.WriteFile(skPath, out, 0600); err != nil {
+		return fmt.Errorf("SaveToDir: write sk: %v", err)
+	}
+	if err := os.Chmod(skPath, 0600); err != nil {
+		return fmt.Errorf("SaveToDir: chmod sk: %v", err)
+	}
+
+	return nil
+}
+
+// LoadFromDir is SaveToDir's inverse: it decrypts sk with passphrase,
+// mlocks the decrypted buffer so it can't be paged to swap, and rebuilds a
+// KeyManager from the three (or more, with rotation keys present) files.
+func LoadFromDir(params ckks.Parameters, dir string, passphrase []byte) (*KeyManager, error) {
+	pkBytes, err := os.ReadFile(filepath.Join(dir, pkFileName))
+	if err != nil {
+		return nil, fmt.Errorf("LoadFromDir: read pk: %v", err)
+	}
+	pk := new(rlwe.PublicKey)
+	if err := pk.UnmarshalBinary(pkBytes); err != nil {
+		return nil, fmt.Errorf("LoadFromDir: unmarshal pk: %v", err)
+	}
+
+	rlkBytes, err := os.ReadFile(filepath.Join(dir, rlkFileName))
+	if err != nil {
+		return nil, fmt.Errorf("LoadFromDir: read rlk: %v", err)
+	}
+	rlk := new(rlwe.RelinearizationKey)
+	if err := rlk.UnmarshalBinary(rlkBytes); err != nil {
+		return nil, fmt.Errorf("LoadFromDir: unmarshal rlk: %v", err)
+	}
+
+	sealed, err := os.ReadFile(filepath.Join(dir, skFileName))
+	if err != nil {
+		return nil, fmt.Errorf("LoadFromDir: read sk: %v", err)
+	}
+
+	aeadProbe, err := chacha20poly1305.New(make([]byte, chacha20poly1305.KeySize))
+	if err != nil {
+		return nil, fmt.Errorf("LoadFromDir: build AEAD: %v", err)
+	}
+	nonceSize := aeadProbe.NonceSize()
+	if len(sealed) < skSaltSize+nonceSize {
+		return nil, fmt.Errorf("LoadFromDir: sk file truncated")
+	}
+	salt := sealed[:skSaltSize]
+	nonce := sealed[skSaltSize : skSaltSize+nonceSize]
+	ciphertext := sealed[skSaltSize+nonceSize:]
+
+	aead, err := chacha20poly1305.New(deriveAEADKey(passphrase, salt))
+	if err != nil {
+		return nil, fmt.Errorf("LoadFromDir: build AEAD: %v", err)
+	}
+	skBytes, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("LoadFromDir: decrypt sk (wrong passphrase?): %v", err)
+	}
+
+	if err := unix.Mlock(skBytes); err != nil {
+		return nil, fmt.Errorf("LoadFromDir: mlock sk: %v", err)
+	}
+
+	sk := new(rlwe.SecretKey)
+	if err := sk.UnmarshalBinary(skBytes); err != nil {
+		unix.Munlock(skBytes)
+		return nil, fmt.Errorf("LoadFromDir: unmarshal sk: %v", err)
+	}
+
+	km := &KeyManager{
+		params:   params,
+		kgen:     rlwe.NewKeyGenerator(params),
+		sk:       sk,
+		pk:       pk,
+		rlk:      rlk,
+		skLocked: skBytes,
+	}
+
+	rotKeys, err := loadRotationKeys(dir)
+	if err != nil {
+		return nil, fmt.Errorf("LoadFromDir: %v", err)
+	}
+	km.rotKeys = rotKeys
+
+	return km, nil
+}
+
+// loadRotationKeys reads however many rotN.bin files SaveToDir wrote,
+// stopping at the first missing index.
+func loadRotationKeys(dir string) ([]*rlwe.GaloisKey, error) {
+	var rotBytes [][]byte
+	for i := 0; ; i++ {
+		b, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("rot%d.bin", i)))
+		if err != nil {
+			break
+		}
+		rotBytes = append(rotBytes, b)
+	}
+	if len(rotBytes) == 0 {
+		return nil, nil
+	}
+	return UnmarshalRotationKeys(rotBytes)
+}
+
+// Zeroize wipes km's mlock'd secret-key buffer and releases the lock, so a
+// shutdown path doesn't leave sk's bytes sitting in memory (or swap, had
+// they not been locked) after the process no longer needs them. km's sk
+// field itself still holds the unmarshalled key; callers that need it gone
+// too should drop all references to km afterward.
+func (km *KeyManager) Zeroize() error {
+	if km.skLocked == nil {
+		return nil
+	}
+	for i := range km.skLocked {
+		km.skLocked[i] = 0
+	}
+	if err := unix.Munlock(km.skLocked); err != nil {
+		return fmt.Errorf("Zeroize: munlock: %v", err)
+	}
+	km.skLocked = nil
+	return nil
+}
@@ -0,0 +1,331 @@
+// threshold.go extends the crypto package (alongside the existing
+// single-key-holder KeyManager) with a t-of-n key ceremony and partial
+// decryption: a ThresholdKeyGen lets N institutions each contribute a
+// secret-key share to a jointly-held CKKS key so no single party's share
+// decrypts anything alone, and a PartialDecryptor turns at least t of
+// those parties' partial decryptions of a ciphertext back into a
+// plaintext score. The aggregated public key and RLK it produces are
+// ordinary *rlwe.PublicKey / *rlwe.RelinearizationKey values — existing
+// callers such as Encryptor.EncryptFloat64 and ckks.NewEvaluator work with
+// them completely unchanged.
+package crypto
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/multiparty"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// ErrBelowThreshold is returned by CombineShares when fewer than the
+// ceremony's threshold parties' shares were supplied. Combining fewer
+// shares wouldn't fail loudly on its own — it would just decode to a
+// plausible-looking wrong value — so CombineShares checks the count
+// itself rather than trusting every caller to.
+type ErrBelowThreshold struct {
+	Have, Need int
+}
+
+func (e *ErrBelowThreshold) Error() string {
+	return fmt.Sprintf("crypto: %d of %d required threshold shares present", e.Have, e.Need)
+}
+
+// ErrShareMismatch is returned by CombineShares when the supplied shares
+// were not all generated against the same ciphertext: PartialDecrypt's
+// share is only meaningful relative to the level and scale of the
+// ciphertext it was computed from, and silently combining shares from two
+// different levels produces noise, not a wrong-but-plausible score.
+type ErrShareMismatch struct {
+	Reason string
+}
+
+func (e *ErrShareMismatch) Error() string {
+	return fmt.Sprintf("crypto: mismatched partial decryption shares: %s", e.Reason)
+}
+
+// ThresholdKeyGen coordinates an n-party, t-of-n CKKS key ceremony: each
+// party samples its own secret-key share locally (NewParty) and only ever
+// publishes the share types the ceremony's protocols emit, never its
+// sk_i. threshold is enforced at decryption time by PartialDecryptor, not
+// here — a ceremony may finish with more live parties than will later
+// attempt to decrypt any one ciphertext.
+type ThresholdKeyGen struct {
+	params    ckks.Parameters
+	n         int
+	threshold int
+
+	pkProto  multiparty.PublicKeyGenProtocol
+	rlkProto multiparty.RelinearizationKeyGenProtocol
+}
+
+// NewThresholdKeyGen builds a coordinator for an n-party, t-of-n CKKS key
+// ceremony under params. threshold must be in (0, n].
+func NewThresholdKeyGen(params ckks.Parameters, n, threshold int) (*ThresholdKeyGen, error) {
+	if n <= 1 {
+		return nil, fmt.Errorf("crypto: threshold ceremony needs at least 2 parties, got %d", n)
+	}
+	if threshold <= 0 || threshold > n {
+		return nil, fmt.Errorf("crypto: threshold %d must be in (0, %d]", threshold, n)
+	}
+
+	return &ThresholdKeyGen{
+		params:    params,
+		n:         n,
+		threshold: threshold,
+		pkProto:   multiparty.NewPublicKeyGenProtocol(params),
+		rlkProto:  multiparty.NewRelinearizationKeyGenProtocol(params),
+	}, nil
+}
+
+// N and Threshold report the ceremony's party count and decryption
+// threshold, so a caller assembling PartialDecryptor.CombineShares inputs
+// can check it collected enough of them before calling.
+func (tkg *ThresholdKeyGen) N() int         { return tkg.n }
+func (tkg *ThresholdKeyGen) Threshold() int { return tkg.threshold }
+
+// ThresholdParty is one institution's persistent state across a key
+// ceremony and every later partial decryption: its own secret-key share
+// sk, which never leaves the process that holds it, plus the ephemeral
+// key the two-round relinearization-key ceremony carries between rounds.
+type ThresholdParty struct {
+	sk    *rlwe.SecretKey
+	ephSk *rlwe.SecretKey
+}
+
+// SecretKeyShare returns party's own sk_i. It exists for tests and for
+// PartialDecrypt's caller, which must supply it per call — the
+// ThresholdKeyGen coordinator itself never stores or sees it.
+func (p *ThresholdParty) SecretKeyShare() *rlwe.SecretKey {
+	return p.sk
+}
+
+// NewParty samples a fresh institution's secret-key share sk_i. Call it
+// once per party per ceremony; the returned ThresholdParty is the only
+// place sk_i is ever held.
+func (tkg *ThresholdKeyGen) NewParty() *ThresholdParty {
+	return &ThresholdParty{
+		sk: rlwe.NewKeyGenerator(tkg.params).GenSecretKeyNew(),
+	}
+}
+
+// CRS is the common reference string every party's GenPublicKeyShare must
+// agree on, generated once (e.g. by whichever party or coordinator starts
+// the ceremony) and broadcast to the rest out of band.
+type CRS = multiparty.CRP
+
+// NewCRS samples a fresh common reference string for a public-key
+// ceremony under params.
+func NewCRS(params ckks.Parameters) (CRS, error) {
+	return multiparty.NewCRPGenerator(params).ReadNew(), nil
+}
+
+// GenPublicKeyShare computes party's share of the t-of-n aggregated
+// public key pk = Σ pk_i under the agreed-upon crs. Every party's share
+// is safe to publish; none of them reveals sk_i.
+func (tkg *ThresholdKeyGen) GenPublicKeyShare(party *ThresholdParty, crs CRS) *multiparty.PublicKeyGenShare {
+	share := tkg.pkProto.AllocateShare()
+	tkg.pkProto.GenShare(party.sk, crs, &share)
+	return &share
+}
+
+// AggregatePublicKey sums every party's public-key share and derives the
+// joint public key pk = Σ pk_i. Existing callers of
+// Encryptor.EncryptFloat64 use the result exactly as they would a
+// single-party KeyManager.PublicKey(): the aggregation is invisible past
+// this point.
+func (tkg *ThresholdKeyGen) AggregatePublicKey(crs CRS, shares ...*multiparty.PublicKeyGenShare) (*rlwe.PublicKey, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("crypto: no public key shares to aggregate")
+	}
+
+	agg := tkg.pkProto.AllocateShare()
+	for _, s := range shares {
+		if err := tkg.pkProto.AggregateShares(agg, *s, &agg); err != nil {
+			return nil, fmt.Errorf("aggregating public key share: %v", err)
+		}
+	}
+
+	pk := rlwe.NewPublicKey(tkg.params)
+	tkg.pkProto.GenPublicKey(agg, crs, pk)
+	return pk, nil
+}
+
+// GenRelinKeyShareRoundOne runs round one of the two-round distributed
+// relinearization-key ceremony for party, against the agreed-upon crs. It
+// stores party's ephemeral key for GenRelinKeyShareRoundTwo to consume
+// later — callers don't pass it around themselves.
+func (tkg *ThresholdKeyGen) GenRelinKeyShareRoundOne(party *ThresholdParty, crs CRS) *multiparty.RelinearizationKeyGenShare {
+	ephSk, share, _ := tkg.rlkProto.AllocateShare()
+	tkg.rlkProto.GenShareRoundOne(party.sk, crs, ephSk, &share)
+	party.ephSk = ephSk
+	return &share
+}
+
+// AggregateRelinKeyShares sums a round's RLK-generation shares. Used
+// twice per ceremony: once to fold every party's round-one shares
+// together before GenRelinKeyShareRoundTwo, and again to fold every
+// party's round-two shares together before FinalizeRelinKey.
+func (tkg *ThresholdKeyGen) AggregateRelinKeyShares(shares ...*multiparty.RelinearizationKeyGenShare) (*multiparty.RelinearizationKeyGenShare, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("crypto: no relinearization key shares to aggregate")
+	}
+
+	_, agg, _ := tkg.rlkProto.AllocateShare()
+	for _, s := range shares {
+		if err := tkg.rlkProto.AggregateShares(agg, *s, &agg); err != nil {
+			return nil, fmt.Errorf("aggregating relinearization key share: %v", err)
+		}
+	}
+	return &agg, nil
+}
+
+// GenRelinKeyShareRoundTwo runs round two for party, given round one's
+// aggregated share. party must be the same ThresholdParty instance that
+// ran GenRelinKeyShareRoundOne (its ephemeral key was stashed there).
+func (tkg *ThresholdKeyGen) GenRelinKeyShareRoundTwo(party *ThresholdParty, round1Agg *multiparty.RelinearizationKeyGenShare) (*multiparty.RelinearizationKeyGenShare, error) {
+	if party.ephSk == nil {
+		return nil, fmt.Errorf("crypto: party has no round-one ephemeral key; call GenRelinKeyShareRoundOne first")
+	}
+
+	_, _, share := tkg.rlkProto.AllocateShare()
+	tkg.rlkProto.GenShareRoundTwo(party.ephSk, party.sk, *round1Agg, &share)
+	return &share, nil
+}
+
+// FinalizeRelinKey derives the usable relinearization key from both
+// rounds' aggregated shares. The result is an ordinary
+// *rlwe.RelinearizationKey — ckks.NewEvaluator takes it exactly as it
+// would one generated by a single-party KeyManager.
+func (tkg *ThresholdKeyGen) FinalizeRelinKey(round1Agg, round2Agg *multiparty.RelinearizationKeyGenShare) *rlwe.RelinearizationKey {
+	rlk := rlwe.NewRelinearizationKey(tkg.params)
+	tkg.rlkProto.GenRelinearizationKey(*round1Agg, *round2Agg, rlk)
+	return rlk
+}
+
+// DefaultSmudgingStdDev is the minimum standard deviation PartialDecrypt's
+// smudging noise e_i must exceed above the scheme's own decryption noise
+// bound, so that a coalition of fewer than threshold parties can't use
+// the statistical structure of an honest share to learn anything about
+// sk_i beyond what the final plaintext already reveals. 2^40 matches the
+// smudging width Lattigo's own multiparty examples use for CKKS at this
+// LogQ range; deployments with a materially different parameter set
+// should size this against their own noise budget instead of trusting
+// the default blindly.
+const DefaultSmudgingStdDev = 1 << 40
+
+// PartialDecryptor runs the collective-decryption half of the threshold
+// scheme: each party turns ct into a share of the plaintext using only
+// its own sk_i, and CombineShares sums at least threshold of those shares
+// back into the value Decryptor.DecryptFloat64 would have produced from
+// the full secret key.
+type PartialDecryptor struct {
+	params    ckks.Parameters
+	encoder   *ckks.Encoder
+	threshold int
+
+	proto multiparty.KeySwitchProtocol
+}
+
+// NewPartialDecryptor builds a PartialDecryptor for a ceremony with the
+// given threshold, smudging ct·sk_i + e_i's noise by smudgingStdDev — pass
+// DefaultSmudgingStdDev absent a parameter-set-specific reason to use a
+// different width.
+func NewPartialDecryptor(params ckks.Parameters, threshold int, smudgingStdDev float64) (*PartialDecryptor, error) {
+	if threshold <= 0 {
+		return nil, fmt.Errorf("crypto: threshold must be positive, got %d", threshold)
+	}
+	if smudgingStdDev <= 0 || math.IsNaN(smudgingStdDev) {
+		return nil, fmt.Errorf("crypto: smudging standard deviation must be positive, got %v", smudgingStdDev)
+	}
+
+	proto, err := multiparty.NewKeySwitchProtocol(params, ring.DiscreteGaussian{Sigma: smudgingStdDev, Bound: 6 * smudgingStdDev})
+	if err != nil {
+		return nil, fmt.Errorf("building partial decryption protocol: %v", err)
+	}
+
+	return &PartialDecryptor{
+		params:    params,
+		encoder:   ckks.NewEncoder(params),
+		threshold: threshold,
+		proto:     proto,
+	}, nil
+}
+
+// PartialDecryptionShare is one party's ct·sk_i + e_i contribution,
+// tagged with the level and scale of the ciphertext it was computed
+// against so CombineShares can reject shares computed against a different
+// one before they're silently summed into noise.
+type PartialDecryptionShare struct {
+	share multiparty.KeySwitchShare
+	level int
+	scale uint64
+}
+
+// PartialDecrypt computes party's share of ct's decryption, ct·sk_i + e_i,
+// switching toward the zero key so that summing at least threshold shares
+// recovers the plaintext without ever reconstructing the joint sk. It
+// never transmits or reconstructs sk_i — only the smudged share leaves
+// this call.
+func (pd *PartialDecryptor) PartialDecrypt(ct *rlwe.Ciphertext, party *ThresholdParty) (*PartialDecryptionShare, error) {
+	share := pd.proto.AllocateShare(ct.Level())
+	zero := rlwe.NewSecretKey(pd.params)
+	if err := pd.proto.GenShare(party.sk, zero, ct, &share); err != nil {
+		return nil, fmt.Errorf("generating partial decryption share: %v", err)
+	}
+
+	return &PartialDecryptionShare{
+		share: share,
+		level: ct.Level(),
+		scale: ct.Scale.Uint64(),
+	}, nil
+}
+
+// CombineShares sums at least threshold parties' partial decryption
+// shares of the same ciphertext and decodes the result to recover the
+// plaintext float64 slice, the same layout DecryptFloat64Slice returns
+// from a single-party secret key.
+//
+// It returns *ErrBelowThreshold if fewer than threshold shares were
+// supplied, and *ErrShareMismatch if the supplied shares weren't all
+// computed against a ciphertext at the same level and scale — combining
+// either case would silently decode to a wrong-but-plausible value
+// instead of failing loudly.
+func (pd *PartialDecryptor) CombineShares(ct *rlwe.Ciphertext, shares ...*PartialDecryptionShare) ([]float64, error) {
+	if len(shares) < pd.threshold {
+		return nil, &ErrBelowThreshold{Have: len(shares), Need: pd.threshold}
+	}
+
+	level, scale := shares[0].level, shares[0].scale
+	for i, s := range shares[1:] {
+		if s.level != level || s.scale != scale {
+			return nil, &ErrShareMismatch{Reason: fmt.Sprintf("share %d at level=%d scale=%d, expected level=%d scale=%d", i+1, s.level, s.scale, level, scale)}
+		}
+	}
+	if ct.Level() != level {
+		return nil, &ErrShareMismatch{Reason: fmt.Sprintf("ciphertext at level=%d, shares computed at level=%d", ct.Level(), level)}
+	}
+
+	agg := pd.proto.AllocateShare(level)
+	for _, s := range shares {
+		if err := pd.proto.AggregateShares(agg, s.share, &agg); err != nil {
+			return nil, fmt.Errorf("aggregating partial decryption shares: %v", err)
+		}
+	}
+
+	// Switching toward the zero key collapses ct to its degree-0 term —
+	// the same polynomial shape as a plaintext — so it can be decoded
+	// directly instead of needing a dedicated "combined share" decoder.
+	ctOut := rlwe.NewCiphertext(pd.params, 0, level)
+	pd.proto.KeySwitch(ct, agg, ctOut)
+	plaintext := &rlwe.Plaintext{OperandQ: ctOut.OperandQ}
+	plaintext.Scale = ct.Scale
+
+	values := make([]float64, pd.params.MaxSlots())
+	if err := pd.encoder.Decode(plaintext, values); err != nil {
+		return nil, fmt.Errorf("decoding combined shares: %v", err)
+	}
+	return values, nil
+}
@@ -0,0 +1,11 @@
+package proto
+
+// scoring.pb.go and scoring_grpc.pb.go are generated from scoring.proto and
+// are not checked in (see .gitignore) — run `go generate ./...` after
+// editing scoring.proto, or directly:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	       --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//	       scoring.proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative scoring.proto
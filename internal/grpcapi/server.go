@@ -0,0 +1,378 @@
+// Package grpcapi exposes app.Application's scoring pipeline as a gRPC
+// service alongside the JSON REST API in internal/server. Score streams
+// ciphertexts as raw bytes instead of base64 inside a JSON body, and chunks
+// anything too large for one frame (e.g. a relinearization key at LogN=14)
+// across multiple messages instead of requiring the whole payload to
+// buffer before it can be parsed. Inference/InferencePacked are unary
+// counterparts to Score for single-message payloads, ServerInfo reports the
+// CKKS parameters a client should match, and InferenceStream mirrors
+// internal/server's StreamCoordinator-backed routes for a client that
+// registers its own keys once per stream rather than relying on the
+// server-held key app.CreditScoringService was built with.
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+
+	"ckks-credit/internal/app"
+	pb "ckks-credit/internal/grpcapi/proto"
+	"ckks-credit/internal/server"
+	"ckks-credit/internal/wire"
+)
+
+// chunkSize bounds how much ciphertext bytes go in a single
+// EncryptedFeatureChunk/EncryptedScoreChunk frame, so a multi-MB relin key
+// at LogN=14 doesn't have to fit in one gRPC message.
+const chunkSize = 1 << 20 // 1 MiB
+
+// Server implements pb.CreditScoringServiceServer over app.Application's
+// CreditScoringService, the same underlying pipeline the REST handlers in
+// internal/server use.
+type Server struct {
+	pb.UnimplementedCreditScoringServiceServer
+	app    *app.Application
+	stream *server.StreamCoordinator
+}
+
+// NewServer wraps application for the gRPC service. streamCoordinator backs
+// InferenceStream the same way it backs internal/server's
+// /api/v1/score/stream routes; pass nil if this server won't expose
+// InferenceStream.
+func NewServer(application *app.Application, streamCoordinator *server.StreamCoordinator) *Server {
+	return &Server{app: application, stream: streamCoordinator}
+}
+
+// ServerInfo reports the CKKS parameters, model version, and supported
+// sigmoid approximations app.CreditScoringService is configured with.
+func (s *Server) ServerInfo(ctx context.Context, _ *pb.Empty) (*pb.ServerInfoResponse, error) {
+	info := s.app.CreditService.ServerInfo()
+
+	logQ := make([]int32, len(info.LogQ))
+	for i, v := range info.LogQ {
+		logQ[i] = int32(v)
+	}
+	logP := make([]int32, len(info.LogP))
+	for i, v := range info.LogP {
+		logP[i] = int32(v)
+	}
+	degrees := make([]int32, len(info.SupportedSigmoidDegrees))
+	for i, v := range info.SupportedSigmoidDegrees {
+		degrees[i] = int32(v)
+	}
+
+	return &pb.ServerInfoResponse{
+		LogN:                    int32(info.LogN),
+		LogQ:                    logQ,
+		LogP:                    logP,
+		LogDefaultScale:         int32(info.LogDefaultScale),
+		MaxLevel:                int32(info.MaxLevel),
+		MaxSlots:                int32(info.MaxSlots),
+		ModelVersion:            info.ModelVersion,
+		SupportedSigmoidDegrees: degrees,
+	}, nil
+}
+
+// Inference is Score's unary counterpart for a single ciphertext that fits
+// in one gRPC message: no chunking, no reassembly bookkeeping.
+//
+// Ciphertext bytes are parsed through internal/wire rather than
+// rlwe.Ciphertext.UnmarshalBinary directly, so a client built against
+// different CKKS parameters (or a ciphertext above this server's
+// params.MaxLevel()) gets a typed wire.ErrParamMismatch/
+// wire.ErrLevelTooHigh instead of silently evaluating against the wrong
+// parameter set.
+func (s *Server) Inference(ctx context.Context, req *pb.InferenceRequest) (*pb.InferenceResponse, error) {
+	params := s.app.CreditService.Params()
+
+	ct, err := wire.UnmarshalCiphertext(req.Ciphertext, params)
+	if err != nil {
+		return nil, fmt.Errorf("grpcapi: Inference: %v", err)
+	}
+
+	result, err := s.app.CreditService.InferCreditScore(ct)
+	if err != nil {
+		return nil, fmt.Errorf("grpcapi: Inference failed: %v", err)
+	}
+
+	resultBytes, err := wire.MarshalCiphertext(result, params)
+	if err != nil {
+		return nil, fmt.Errorf("grpcapi: Inference marshal result: %v", err)
+	}
+	return &pb.InferenceResponse{Ciphertext: resultBytes}, nil
+}
+
+// InferencePacked is InferCreditScoreBatch's unary RPC: one ciphertext per
+// model feature, each packing that feature's value for every applicant
+// across its slots. See Inference for why ciphertexts go through
+// internal/wire instead of UnmarshalBinary directly.
+func (s *Server) InferencePacked(ctx context.Context, req *pb.InferencePackedRequest) (*pb.InferencePackedResponse, error) {
+	params := s.app.CreditService.Params()
+
+	matrix := make([]*rlwe.Ciphertext, len(req.FeatureCiphertexts))
+	for i, b := range req.FeatureCiphertexts {
+		ct, err := wire.UnmarshalCiphertext(b, params)
+		if err != nil {
+			return nil, fmt.Errorf("grpcapi: InferencePacked feature %d: %v", i, err)
+		}
+		matrix[i] = ct
+	}
+
+	result, err := s.app.CreditService.InferCreditScorePacked(matrix, int(req.NumApplicants))
+	if err != nil {
+		return nil, fmt.Errorf("grpcapi: InferencePacked failed: %v", err)
+	}
+
+	resultBytes, err := wire.MarshalCiphertext(result, params)
+	if err != nil {
+		return nil, fmt.Errorf("grpcapi: InferencePacked marshal result: %v", err)
+	}
+	return &pb.InferencePackedResponse{Ciphertext: resultBytes}, nil
+}
+
+// InferenceStream registers the stream's first message as the session's
+// relinearization/rotation keys, then scores every ciphertext message that
+// follows against them via s.stream, the gRPC counterpart to
+// internal/server's StreamCoordinator-backed REST routes.
+func (s *Server) InferenceStream(stream pb.CreditScoringService_InferenceStreamServer) error {
+	if s.stream == nil {
+		return fmt.Errorf("grpcapi: InferenceStream not configured on this server")
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("grpcapi: InferenceStream recv keys: %v", err)
+	}
+	keys := first.GetKeys()
+	if keys == nil {
+		return fmt.Errorf("grpcapi: InferenceStream first message must register keys")
+	}
+
+	rlk := new(rlwe.RelinearizationKey)
+	if err := rlk.UnmarshalBinary(keys.RelinearizationKey); err != nil {
+		return fmt.Errorf("grpcapi: InferenceStream unmarshal rlk: %v", err)
+	}
+	rotk := make([]*rlwe.GaloisKey, len(keys.GaloisKeys))
+	for i, b := range keys.GaloisKeys {
+		gk := new(rlwe.GaloisKey)
+		if err := gk.UnmarshalBinary(b); err != nil {
+			return fmt.Errorf("grpcapi: InferenceStream unmarshal galois key %d: %v", i, err)
+		}
+		rotk[i] = gk
+	}
+
+	sessionID, err := s.stream.RegisterSession(rlk, rotk)
+	if err != nil {
+		return fmt.Errorf("grpcapi: InferenceStream register keys: %v", err)
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("grpcapi: InferenceStream recv: %v", err)
+		}
+
+		ctBytes := req.GetCiphertext()
+		if ctBytes == nil {
+			if err := stream.Send(&pb.InferenceStreamResponse{Error: "expected a ciphertext payload after key registration"}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ct := new(rlwe.Ciphertext)
+		if err := ct.UnmarshalBinary(ctBytes); err != nil {
+			if err := stream.Send(&pb.InferenceStreamResponse{Error: fmt.Sprintf("unmarshal ciphertext: %v", err)}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := s.stream.ScoreSession(sessionID, ct)
+		if err != nil {
+			if err := stream.Send(&pb.InferenceStreamResponse{Error: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		resultBytes, err := result.MarshalBinary()
+		if err != nil {
+			if err := stream.Send(&pb.InferenceStreamResponse{Error: fmt.Sprintf("marshal result: %v", err)}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := stream.Send(&pb.InferenceStreamResponse{Ciphertext: resultBytes}); err != nil {
+			return err
+		}
+	}
+}
+
+// pendingRequest accumulates EncryptedFeatureChunk frames for one
+// request_id until final_chunk arrives, reassembling the ciphertext and, on
+// the first request_id per stream, the relinearization key.
+type pendingRequest struct {
+	chunks map[uint32][]byte
+	rlk    map[uint32][]byte
+}
+
+func newPendingRequest() *pendingRequest {
+	return &pendingRequest{chunks: make(map[uint32][]byte), rlk: make(map[uint32][]byte)}
+}
+
+func (p *pendingRequest) add(chunk *pb.EncryptedFeatureChunk) {
+	p.chunks[chunk.Sequence] = chunk.CiphertextPart
+	if len(chunk.RlkPart) > 0 {
+		p.rlk[chunk.Sequence] = chunk.RlkPart
+	}
+}
+
+func (p *pendingRequest) assembleCiphertext() []byte {
+	return assembleInOrder(p.chunks)
+}
+
+// assembleRLK reassembles rlk_part across a request's frames. It's currently
+// unused by handleRequest — app.Application's InferenceEngine is built with
+// its own relinearization key at startup the same way the REST handlers
+// are — but the field stays part of the wire protocol for a future
+// per-request or per-key-version evaluator (see internal/keystore).
+func (p *pendingRequest) assembleRLK() []byte {
+	if len(p.rlk) == 0 {
+		return nil
+	}
+	return assembleInOrder(p.rlk)
+}
+
+func assembleInOrder(parts map[uint32][]byte) []byte {
+	sequences := make([]uint32, 0, len(parts))
+	for seq := range parts {
+		sequences = append(sequences, seq)
+	}
+	sort.Slice(sequences, func(i, j int) bool { return sequences[i] < sequences[j] })
+
+	var out []byte
+	for _, seq := range sequences {
+		out = append(out, parts[seq]...)
+	}
+	return out
+}
+
+// Score implements the bidirectional streaming RPC: it reassembles each
+// request_id's chunks as they arrive, runs app.Application's scoring
+// pipeline once final_chunk is seen, and streams the encrypted result back
+// chunked the same way.
+func (s *Server) Score(stream pb.CreditScoringService_ScoreServer) error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	pending := make(map[string]*pendingRequest)
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			wg.Wait()
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("grpcapi: Score recv failed: %v", err)
+		}
+
+		mu.Lock()
+		req, ok := pending[chunk.RequestId]
+		if !ok {
+			req = newPendingRequest()
+			pending[chunk.RequestId] = req
+		}
+		req.add(chunk)
+		mu.Unlock()
+
+		if !chunk.FinalChunk {
+			continue
+		}
+
+		mu.Lock()
+		delete(pending, chunk.RequestId)
+		mu.Unlock()
+
+		wg.Add(1)
+		go func(requestID string, req *pendingRequest) {
+			defer wg.Done()
+			s.handleRequest(stream, requestID, req, &mu)
+		}(chunk.RequestId, req)
+	}
+}
+
+// handleRequest scores one fully-reassembled request and streams the
+// result (or an error frame) back. Sends are serialized through mu since
+// grpc.ServerStream.Send is not safe to call from multiple goroutines at
+// once.
+func (s *Server) handleRequest(stream pb.CreditScoringService_ScoreServer, requestID string, req *pendingRequest, mu *sync.Mutex) {
+	ct := new(rlwe.Ciphertext)
+	if err := ct.UnmarshalBinary(req.assembleCiphertext()); err != nil {
+		s.sendError(stream, mu, requestID, fmt.Sprintf("unmarshal ciphertext: %v", err))
+		return
+	}
+
+	result, err := s.app.CreditService.InferCreditScore(ct)
+	if err != nil {
+		s.sendError(stream, mu, requestID, fmt.Sprintf("inference failed: %v", err))
+		return
+	}
+
+	resultBytes, err := result.MarshalBinary()
+	if err != nil {
+		s.sendError(stream, mu, requestID, fmt.Sprintf("marshal result: %v", err))
+		return
+	}
+
+	parts := splitChunks(resultBytes, chunkSize)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for sequence, part := range parts {
+		if err := stream.Send(&pb.EncryptedScoreChunk{
+			RequestId:      requestID,
+			Sequence:       uint32(sequence),
+			FinalChunk:     sequence == len(parts)-1,
+			CiphertextPart: part,
+		}); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) sendError(stream pb.CreditScoringService_ScoreServer, mu *sync.Mutex, requestID, message string) {
+	mu.Lock()
+	defer mu.Unlock()
+	stream.Send(&pb.EncryptedScoreChunk{
+		RequestId:  requestID,
+		FinalChunk: true,
+		Error:      message,
+	})
+}
+
+// splitChunks breaks data into size-byte slices, the same framing
+// EncryptedFeatureChunk/EncryptedScoreChunk use for anything too large for
+// one gRPC message.
+func splitChunks(data []byte, size int) [][]byte {
+	if len(data) == 0 {
+		return [][]byte{{}}
+	}
+	var out [][]byte
+	for start := 0; start < len(data); start += size {
+		end := start + size
+		if end > len(data) {
+			end = len(data)
+		}
+		out = append(out, data[start:end])
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+// Package boot wires Lattigo's bootstrapping.Parameters/bootstrapping.Evaluator
+// into the scorer path so a chain of models — e.g. linear scorer, sigmoid
+// poly, risk-band poly, ensemble weighted average — can run back to back
+// under one encryption instead of each stage needing its own fresh
+// ciphertext. It generalizes the single ensureLevel check in
+// internal/app/bootstrap.go into a reusable evaluator that refreshes
+// whichever ciphertext is about to run out of levels, for however many
+// stages a chain has.
+package boot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks/bootstrapping"
+)
+
+// Stage is one step of a model chain run through BootstrappedEvaluator.RunChain.
+// It mirrors backend/sigmoid.Approximation's Evaluate/RequiredDepth shape so
+// a sigmoid.PSApprox or internal/app.EncryptedSigmoid-style step can be
+// wrapped in a Stage without internal/he/boot importing either module.
+type Stage interface {
+	Name() string
+	RequiredDepth() int
+	Evaluate(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, params ckks.Parameters) (*rlwe.Ciphertext, error)
+}
+
+// DefaultThreshold is the minimum remaining level EnsureLevel tolerates
+// before it bootstraps, matching the single spare level
+// internal/app.InferenceEngine.ensureLevel assumes is always enough to
+// decode a freshly-summed result.
+const DefaultThreshold = 1
+
+// BootstrappedEvaluator pairs a ckks.Evaluator with a bootstrapping.Evaluator
+// so RunChain can refresh a ciphertext's level mid-chain instead of failing
+// once the budget a single parameter set was sized for runs out.
+type BootstrappedEvaluator struct {
+	params    ckks.Parameters
+	evaluator *ckks.Evaluator
+	btpEval   *bootstrapping.Evaluator
+	threshold int
+}
+
+// NewBootstrappedEvaluator generates bootstrapping evaluation keys from sk
+// under btpParams and builds the evaluator RunChain bootstraps through.
+// threshold is the minimum level a ciphertext may fall to before a stage
+// needing more depth triggers a bootstrap; pass DefaultThreshold absent a
+// reason to do otherwise.
+func NewBootstrappedEvaluator(params ckks.Parameters, btpParams bootstrapping.Parameters, evaluator *ckks.Evaluator, sk *rlwe.SecretKey, threshold int) (*BootstrappedEvaluator, error) {
+	btpEvk, _, err := btpParams.GenEvaluationKeys(sk)
+	if err != nil {
+		return nil, fmt.Errorf("BootstrappedEvaluator: bootstrapping evaluation key generation failed: %v", err)
+	}
+
+	btpEval, err := bootstrapping.NewEvaluator(btpParams, btpEvk)
+	if err != nil {
+		return nil, fmt.Errorf("BootstrappedEvaluator: bootstrapping evaluator creation failed: %v", err)
+	}
+
+	return &BootstrappedEvaluator{
+		params:    params,
+		evaluator: evaluator,
+		btpEval:   btpEval,
+		threshold: threshold,
+	}, nil
+}
+
+// BootstrapMetrics reports what a single refresh cost and recovered, for
+// --bootstrap benchmark modes deciding whether enabling bootstrapping is
+// worth it for a given model depth.
+type BootstrapMetrics struct {
+	Latency     time.Duration
+	LevelBefore int
+	LevelAfter  int
+}
+
+// Bootstrap refreshes ct unconditionally and reports the latency and level
+// recovered. Callers that only want a refresh when needed should use
+// EnsureLevel instead; Bootstrap is for benchmark code that wants to measure
+// every refresh it triggers.
+func (be *BootstrappedEvaluator) Bootstrap(ct *rlwe.Ciphertext) (*rlwe.Ciphertext, BootstrapMetrics, error) {
+	levelBefore := ct.Level()
+	start := time.Now()
+	refreshed, err := be.btpEval.Bootstrap(ct)
+	if err != nil {
+		return nil, BootstrapMetrics{}, fmt.Errorf("BootstrappedEvaluator.Bootstrap failed: %v", err)
+	}
+	metrics := BootstrapMetrics{
+		Latency:     time.Since(start),
+		LevelBefore: levelBefore,
+		LevelAfter:  refreshed.Level(),
+	}
+	return refreshed, metrics, nil
+}
+
+// EnsureLevel bootstraps ct in place if its remaining level is at or below
+// be.threshold short of needed, and returns ct unchanged otherwise. It is
+// the multi-stage generalization of internal/app.InferenceEngine.ensureLevel.
+func (be *BootstrappedEvaluator) EnsureLevel(ct *rlwe.Ciphertext, needed int) (*rlwe.Ciphertext, error) {
+	if ct.Level()-be.threshold >= needed {
+		return ct, nil
+	}
+
+	refreshed, _, err := be.Bootstrap(ct)
+	if err != nil {
+		return nil, fmt.Errorf("EnsureLevel: %v", err)
+	}
+	return refreshed, nil
+}
+
+// RunChain evaluates stages in order on ct, bootstrapping before any stage
+// whose RequiredDepth the ciphertext's current level can't cover. It lets a
+// linear scorer feed a sigmoid poly feed a risk-band poly feed an ensemble
+// average all run under the one encryption, regardless of how many levels
+// the parameter set was sized for.
+func (be *BootstrappedEvaluator) RunChain(ct *rlwe.Ciphertext, stages []Stage) (*rlwe.Ciphertext, error) {
+	current := ct
+	for _, stage := range stages {
+		refreshed, err := be.EnsureLevel(current, stage.RequiredDepth())
+		if err != nil {
+			return nil, fmt.Errorf("RunChain: stage %q: %v", stage.Name(), err)
+		}
+		current = refreshed
+
+		result, err := stage.Evaluate(be.evaluator, current, be.params)
+		if err != nil {
+			return nil, fmt.Errorf("RunChain: stage %q evaluation failed: %v", stage.Name(), err)
+		}
+		current = result
+	}
+	return current, nil
+}
@@ -0,0 +1,376 @@
+// Package keystore persists versioned CKKS key sets to disk so the server
+// no longer loses sk/pk/rlk/Galois keys on every restart. Each call to
+// Rotate tags a freshly generated key set with a monotonic version and
+// creation timestamp, encrypts it at rest with an AES-GCM key derived via
+// scrypt from KEYSTORE_PASSPHRASE, and keeps the previous Store.retain
+// versions loaded so clients holding ciphertexts encrypted under an older
+// version can still reach them during the grace period before they're
+// pruned.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltSize     = 16
+)
+
+// DefaultRetain is how many versions before the latest Rotate keeps loaded
+// and on disk, absent an explicit retain count.
+const DefaultRetain = 3
+
+// KeySet is one versioned CKKS key set: sk for threshold/MPC decryption
+// flows, pk and rlk for encryption and relinearization, and whichever
+// Galois keys InferenceEngine's rotate-and-sum tree was configured with.
+type KeySet struct {
+	Version    int
+	CreatedAt  time.Time
+	SK         *rlwe.SecretKey
+	PK         *rlwe.PublicKey
+	RLK        *rlwe.RelinearizationKey
+	GaloisKeys []*rlwe.GaloisKey
+}
+
+// Store persists KeySets under dir, encrypted at rest, and keeps the most
+// recent retain versions available in memory so requests pinned to an
+// older X-Key-Version (see internal/server) keep working through a
+// rotation's grace period.
+type Store struct {
+	dir        string
+	params     ckks.Parameters
+	galoisRots []int
+	retain     int
+	passphrase []byte
+
+	mu       sync.RWMutex
+	versions map[int]*KeySet
+	latest   int
+}
+
+// NewStore opens (or creates) a keystore rooted at dir for params, deriving
+// its at-rest encryption key from KEYSTORE_PASSPHRASE. galoisRots are the
+// rotation steps each generated KeySet's Galois keys should cover, e.g. the
+// power-of-two steps InferenceEngine's rotate-and-sum tree needs; pass nil
+// if the deployment has no use for Galois keys. retain <= 0 falls back to
+// DefaultRetain.
+func NewStore(dir string, params ckks.Parameters, galoisRots []int, retain int) (*Store, error) {
+	passphrase := os.Getenv("KEYSTORE_PASSPHRASE")
+	if passphrase == "" {
+		return nil, fmt.Errorf("keystore: KEYSTORE_PASSPHRASE is not set")
+	}
+	if retain <= 0 {
+		retain = DefaultRetain
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("keystore: failed to create %s: %v", dir, err)
+	}
+
+	s := &Store{
+		dir:        dir,
+		params:     params,
+		galoisRots: galoisRots,
+		retain:     retain,
+		passphrase: []byte(passphrase),
+		versions:   make(map[int]*KeySet),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("keystore: failed to load existing versions: %v", err)
+	}
+	return s, nil
+}
+
+// Rotate generates a fresh key set, assigns it the next monotonic version,
+// persists it to disk, and prunes versions older than the retention window.
+func (s *Store) Rotate() (*KeySet, error) {
+	kgen := ckks.NewKeyGenerator(s.params)
+	sk := kgen.GenSecretKeyNew()
+	pk := kgen.GenPublicKeyNew(sk)
+	rlk := kgen.GenRelinearizationKeyNew(sk)
+
+	var gks []*rlwe.GaloisKey
+	if len(s.galoisRots) > 0 {
+		galEls := make([]uint64, len(s.galoisRots))
+		for i, step := range s.galoisRots {
+			galEls[i] = s.params.GaloisElement(step)
+		}
+		gks = kgen.GenGaloisKeysNew(galEls, sk)
+	}
+
+	s.mu.Lock()
+	version := s.latest + 1
+	ks := &KeySet{Version: version, CreatedAt: time.Now(), SK: sk, PK: pk, RLK: rlk, GaloisKeys: gks}
+	s.versions[version] = ks
+	s.latest = version
+	s.mu.Unlock()
+
+	if err := s.persist(ks); err != nil {
+		return nil, fmt.Errorf("keystore: failed to persist version %d: %v", version, err)
+	}
+	s.prune()
+	return ks, nil
+}
+
+// Get returns the key set tagged with version, or an error if it was never
+// generated or has already aged out of the retention window.
+func (s *Store) Get(version int) (*KeySet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ks, ok := s.versions[version]
+	if !ok {
+		return nil, fmt.Errorf("keystore: key version %d is unknown or past its grace period", version)
+	}
+	return ks, nil
+}
+
+// Latest returns the most recently rotated key set.
+func (s *Store) Latest() (*KeySet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.latest == 0 {
+		return nil, fmt.Errorf("keystore: no key set has been generated yet")
+	}
+	return s.versions[s.latest], nil
+}
+
+// prune drops versions older than the retention window from memory and disk.
+func (s *Store) prune() {
+	s.mu.Lock()
+	cutoff := s.latest - s.retain
+	stale := make([]int, 0)
+	for version := range s.versions {
+		if version <= cutoff {
+			stale = append(stale, version)
+			delete(s.versions, version)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, version := range stale {
+		os.Remove(s.path(version))
+	}
+}
+
+func (s *Store) path(version int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("v%d.keyset", version))
+}
+
+// serializedKeySet is the plaintext payload sealed inside each version's
+// encrypted envelope on disk.
+type serializedKeySet struct {
+	Version    int       `json:"version"`
+	CreatedAt  time.Time `json:"createdAt"`
+	SK         []byte    `json:"sk"`
+	PK         []byte    `json:"pk"`
+	RLK        []byte    `json:"rlk"`
+	GaloisKeys [][]byte  `json:"galoisKeys"`
+}
+
+// envelope is the on-disk format: an AES-GCM ciphertext plus the scrypt
+// salt and GCM nonce needed to rederive the key and open it.
+type envelope struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (s *Store) persist(ks *KeySet) error {
+	skBytes, err := ks.SK.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal sk: %v", err)
+	}
+	pkBytes, err := ks.PK.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal pk: %v", err)
+	}
+	rlkBytes, err := ks.RLK.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal rlk: %v", err)
+	}
+	galoisBytes := make([][]byte, len(ks.GaloisKeys))
+	for i, gk := range ks.GaloisKeys {
+		b, err := gk.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("marshal galois key %d: %v", i, err)
+		}
+		galoisBytes[i] = b
+	}
+
+	plaintext, err := json.Marshal(serializedKeySet{
+		Version:    ks.Version,
+		CreatedAt:  ks.CreatedAt,
+		SK:         skBytes,
+		PK:         pkBytes,
+		RLK:        rlkBytes,
+		GaloisKeys: galoisBytes,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal key set: %v", err)
+	}
+
+	env, err := s.seal(plaintext)
+	if err != nil {
+		return fmt.Errorf("seal key set: %v", err)
+	}
+
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("marshal envelope: %v", err)
+	}
+
+	return os.WriteFile(s.path(ks.Version), envBytes, 0600)
+}
+
+func (s *Store) seal(plaintext []byte) (*envelope, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %v", err)
+	}
+
+	key, err := scrypt.Key(s.passphrase, salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("build cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("build GCM: %v", err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %v", err)
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+	return &envelope{Salt: salt, Nonce: nonce, Ciphertext: ciphertext}, nil
+}
+
+func (s *Store) open(env *envelope) ([]byte, error) {
+	key, err := scrypt.Key(s.passphrase, env.Salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("build cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("build GCM: %v", err)
+	}
+
+	plaintext, err := gcm.Open(nil, env.Nonce, env.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt (wrong KEYSTORE_PASSPHRASE?): %v", err)
+	}
+	return plaintext, nil
+}
+
+// load reads every v*.keyset file in s.dir, decrypts it, and repopulates
+// s.versions/s.latest, so a restarted server recovers exactly the key sets
+// it had before instead of generating fresh ones.
+func (s *Store) load() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("read %s: %v", s.dir, err)
+	}
+
+	versions := make([]int, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".keyset") {
+			continue
+		}
+
+		envBytes, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("read %s: %v", entry.Name(), err)
+		}
+
+		var env envelope
+		if err := json.Unmarshal(envBytes, &env); err != nil {
+			return fmt.Errorf("parse %s: %v", entry.Name(), err)
+		}
+
+		plaintext, err := s.open(&env)
+		if err != nil {
+			return fmt.Errorf("open %s: %v", entry.Name(), err)
+		}
+
+		var serialized serializedKeySet
+		if err := json.Unmarshal(plaintext, &serialized); err != nil {
+			return fmt.Errorf("parse key set in %s: %v", entry.Name(), err)
+		}
+
+		ks, err := deserializeKeySet(&serialized)
+		if err != nil {
+			return fmt.Errorf("decode key set in %s: %v", entry.Name(), err)
+		}
+
+		s.versions[ks.Version] = ks
+		versions = append(versions, ks.Version)
+	}
+
+	sort.Ints(versions)
+	if len(versions) > 0 {
+		s.latest = versions[len(versions)-1]
+	}
+	return nil
+}
+
+func deserializeKeySet(serialized *serializedKeySet) (*KeySet, error) {
+	sk := new(rlwe.SecretKey)
+	if err := sk.UnmarshalBinary(serialized.SK); err != nil {
+		return nil, fmt.Errorf("unmarshal sk: %v", err)
+	}
+	pk := new(rlwe.PublicKey)
+	if err := pk.UnmarshalBinary(serialized.PK); err != nil {
+		return nil, fmt.Errorf("unmarshal pk: %v", err)
+	}
+	rlk := new(rlwe.RelinearizationKey)
+	if err := rlk.UnmarshalBinary(serialized.RLK); err != nil {
+		return nil, fmt.Errorf("unmarshal rlk: %v", err)
+	}
+	galoisKeys := make([]*rlwe.GaloisKey, len(serialized.GaloisKeys))
+	for i, b := range serialized.GaloisKeys {
+		gk := new(rlwe.GaloisKey)
+		if err := gk.UnmarshalBinary(b); err != nil {
+			return nil, fmt.Errorf("unmarshal galois key %d: %v", i, err)
+		}
+		galoisKeys[i] = gk
+	}
+
+	return &KeySet{
+		Version:    serialized.Version,
+		CreatedAt:  serialized.CreatedAt,
+		SK:         sk,
+		PK:         pk,
+		RLK:        rlk,
+		GaloisKeys: galoisKeys,
+	}, nil
+}
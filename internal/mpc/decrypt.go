@@ -0,0 +1,183 @@
+package mpc
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// DefaultSmudgingSigma is the standard deviation of the Gaussian noise each
+// party adds to its partial decryption. It must be wide enough that summing
+// up to N-1 honest parties' noise (the worst case a dishonest coordinator
+// could exploit by dropping shares one at a time) still statistically hides
+// a single party's share, yet narrow enough not to perturb the decoded score.
+const DefaultSmudgingSigma = 1 << 20
+
+// PartialDecryption is one party's contribution toward decrypting a
+// ciphertext: s_i * c1 + e_i for that party's share s_i of sk and fresh
+// smudging noise e_i, in the same per-limb coefficient layout as Share.
+type PartialDecryption struct {
+	PartyIndex int
+	Coeffs     [][]uint64
+}
+
+// PartialDecrypt computes this party's contribution toward decrypting ct,
+// using share (already verified against the dealer's commitments via
+// VerifyShare) and ct's second component c1. smudgingSigma is the standard
+// deviation of the Gaussian noise added to mask share from the coordinator
+// that will later combine every party's partial; pass DefaultSmudgingSigma
+// unless a caller has a specific reason to deviate.
+func PartialDecrypt(params ckks.Parameters, share *Share, ct *rlwe.Ciphertext, smudgingSigma float64) (*PartialDecryption, error) {
+	level := ct.Level()
+	if level+1 > len(share.Coeffs) {
+		return nil, fmt.Errorf("mpc: PartialDecrypt ciphertext level %d exceeds share's %d limbs", level, len(share.Coeffs))
+	}
+
+	moduli := params.RingQ().ModuliChain()
+	c1 := ct.Value[1].Coeffs
+
+	out := &PartialDecryption{PartyIndex: share.PartyIndex, Coeffs: make([][]uint64, level+1)}
+	for limb := 0; limb <= level; limb++ {
+		mod := moduli[limb]
+		n := len(c1[limb])
+		out.Coeffs[limb] = make([]uint64, n)
+		for j := 0; j < n; j++ {
+			product := mulMod(share.Coeffs[limb][j], c1[limb][j], mod)
+			noise := sampleSmudgingNoise(smudgingSigma, mod)
+			out.Coeffs[limb][j] = addMod(product, noise, mod)
+		}
+	}
+	return out, nil
+}
+
+// sampleSmudgingNoise draws from a Gaussian with the given standard
+// deviation, rounds to the nearest integer, and reduces it into [0, mod) so
+// it can be added to an RNS-domain coefficient with addMod (negative samples
+// wrap around, exactly as CKKS treats negative plaintext/noise coefficients
+// in each RNS limb). The noise exists to keep a party's share hidden from
+// the coordinator, so it must come from a source an adversary can't predict
+// or reseed — hence crypto/rand via cryptoNormFloat64 rather than math/rand.
+func sampleSmudgingNoise(sigma float64, mod uint64) uint64 {
+	sample := int64(math.Round(cryptoNormFloat64() * sigma))
+	reduced := new(big.Int).Mod(big.NewInt(sample), new(big.Int).SetUint64(mod))
+	return reduced.Uint64()
+}
+
+// cryptoNormFloat64 draws a standard-normal sample via the Box-Muller
+// transform over crypto/rand-sourced uniform floats, giving
+// sampleSmudgingNoise's caller the unpredictability math/rand can't.
+func cryptoNormFloat64() float64 {
+	u1, u2 := cryptoUniformFloat64(), cryptoUniformFloat64()
+	for u1 == 0 {
+		u1 = cryptoUniformFloat64()
+	}
+	return math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+}
+
+// cryptoUniformFloat64 returns a uniform sample in [0, 1) backed by
+// crypto/rand.
+func cryptoUniformFloat64() float64 {
+	const mantissaBits = 53
+	n, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), mantissaBits))
+	if err != nil {
+		panic(fmt.Sprintf("mpc: failed to read secure randomness: %v", err))
+	}
+	return float64(n.Int64()) / float64(int64(1)<<mantissaBits)
+}
+
+// Combine Lagrange-interpolates th.T or more partial decryptions to recover
+// s*c1 at x=0, subtracts it from ct's c0, and decodes the result. It returns
+// an error, rather than silently proceeding with too few or unverifiable
+// partials, if fewer than th.T distinct parties' partials are present: a
+// caller missing parties (e.g. to a combine timeout) must not get a result
+// that looks valid but quietly used noise as a stand-in for a missing share.
+func Combine(params ckks.Parameters, ct *rlwe.Ciphertext, partials []*PartialDecryption, th Threshold) (*rlwe.Plaintext, error) {
+	if len(partials) < th.T {
+		return nil, fmt.Errorf("mpc: Combine needs at least %d partials, got %d", th.T, len(partials))
+	}
+
+	level := ct.Level()
+	moduli := params.RingQ().ModuliChain()
+
+	indices := make([]uint64, len(partials))
+	for i, p := range partials {
+		indices[i] = uint64(p.PartyIndex)
+	}
+
+	reconstructed := make([][]uint64, level+1)
+	for limb := 0; limb <= level; limb++ {
+		mod := moduli[limb]
+		n := len(ct.Value[1].Coeffs[limb])
+		reconstructed[limb] = make([]uint64, n)
+
+		lambdas := make([]uint64, len(partials))
+		for i := range partials {
+			lambdas[i] = lagrangeCoefficientAtZero(indices, i, mod)
+		}
+
+		for j := 0; j < n; j++ {
+			acc := uint64(0)
+			for i, p := range partials {
+				acc = addMod(acc, mulMod(lambdas[i], p.Coeffs[limb][j], mod), mod)
+			}
+			reconstructed[limb][j] = acc
+		}
+	}
+
+	pt := ckks.NewPlaintext(params, level)
+	for limb := 0; limb <= level; limb++ {
+		copy(pt.Value.Coeffs[limb], ct.Value[0].Coeffs[limb])
+		mod := moduli[limb]
+		for j, v := range reconstructed[limb] {
+			pt.Value.Coeffs[limb][j] = (pt.Value.Coeffs[limb][j] - v + mod) % mod
+		}
+	}
+	return pt, nil
+}
+
+// lagrangeCoefficientAtZero computes lambda_i(0) = prod_{j != i} x_j / (x_j - x_i)
+// mod m, the weight partials[i] contributes when interpolating f(0) from
+// the points (x_0, f(x_0)), ..., (x_k, f(x_k)).
+func lagrangeCoefficientAtZero(xs []uint64, i int, m uint64) uint64 {
+	num, den := uint64(1), uint64(1)
+	for j, xj := range xs {
+		if j == i {
+			continue
+		}
+		num = mulMod(num, xj, m)
+		diff := (xj + m - xs[i]%m) % m
+		den = mulMod(den, diff, m)
+	}
+	return mulMod(num, modInverse(den, m), m)
+}
+
+// modInverse returns a^-1 mod m via Fermat's little theorem (m is one of
+// CKKS' NTT-friendly RNS primes, so it's prime and this always exists for
+// a != 0 mod m).
+func modInverse(a, m uint64) uint64 {
+	return modPow(a, m-2, m)
+}
+
+// DecodeScore decodes the plaintext Combine recovered into a credit score,
+// mirroring crypto.Decryptor.DecryptCreditScore's clamp to the [300, 850]
+// range; the only difference from that single-key path is that there is no
+// rlwe.Decryptor step here, since Combine already subtracted s*c1 from c0.
+func DecodeScore(params ckks.Parameters, pt *rlwe.Plaintext) (float64, error) {
+	encoder := ckks.NewEncoder(params)
+	values := make([]float64, params.MaxSlots())
+	if err := encoder.Decode(pt, values); err != nil {
+		return 0, fmt.Errorf("mpc: DecodeScore decoding failed: %v", err)
+	}
+
+	score := values[0]
+	if score < 300 {
+		score = 300
+	} else if score > 850 {
+		score = 850
+	}
+	return score, nil
+}
@@ -0,0 +1,255 @@
+// Package mpc implements threshold decryption of CreditScoringService's
+// CKKS secret key: instead of one operator holding sk outright,
+// SplitSecretKey divides it into N Shamir shares so that any T of them can
+// jointly decrypt a score (via PartialDecrypt and Combine) while no T-1
+// parties, nor the coordinator that combines their partials, ever sees sk
+// itself. Shares are verified against Feldman commitments before they're
+// trusted, so a dealer (or a party) handing out a corrupted share is caught
+// instead of silently producing a wrong score.
+package mpc
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// Threshold pairs the number of shares a dealer hands out (N) with the
+// minimum number needed to reconstruct (T). Parties join a decryption round
+// over HTTP rather than being fixed at compile time, so this is carried as
+// data rather than encoded as, say, N separate types.
+type Threshold struct {
+	T int
+	N int
+}
+
+func (th Threshold) validate() error {
+	if th.T < 1 || th.N < th.T {
+		return fmt.Errorf("mpc: invalid threshold T=%d, N=%d (need 1 <= T <= N)", th.T, th.N)
+	}
+	return nil
+}
+
+// Share is one party's slice of sk: for every RNS limb of sk's modulus chain
+// and every ring coefficient, Coeffs holds f(PartyIndex) for the random
+// degree-(T-1) polynomial the dealer sampled with f(0) equal to that
+// coefficient of sk. PartyIndex is 1-based; x=0 is reserved for the secret
+// itself and is never handed to a party.
+type Share struct {
+	PartyIndex int
+	Coeffs     [][]uint64 // [limb][ring coefficient], same shape as sk's ring.Poly
+}
+
+// Commitments are the dealer's public, per-coefficient Feldman commitment
+// vectors: Commitments[limb][coeff][k] = g_limb^(a_k) mod q_limb, where a_0
+// is the shared secret coefficient and a_1..a_{T-1} are that coefficient's
+// sharing polynomial's remaining coefficients. Any party can use these to
+// check its own Share without learning sk, and a coordinator can reject a
+// Share that doesn't match before wasting a decryption round on it.
+type Commitments struct {
+	Threshold Threshold
+	Moduli    []uint64     // q_limb, one per RNS limb, matching Share.Coeffs' outer index
+	Points    [][][]uint64 // [limb][coeff][k]
+}
+
+// SetupResult is everything a dealer produces for one run of SplitSecretKey:
+// the N shares (index i holds party i+1's share) and the commitments every
+// party verifies its share against.
+type SetupResult struct {
+	Shares      []*Share
+	Commitments *Commitments
+}
+
+// SplitSecretKey Shamir-shares sk coefficient-wise across th.N parties with
+// reconstruction threshold th.T. Each ring coefficient of each RNS limb gets
+// its own independent random polynomial of degree th.T-1, modulo that limb's
+// modulus, so the reconstructed value is still a valid representative of sk
+// under the same RNS basis. q gives the modulus chain to share against (the
+// ciphertext modulus Q, not the key-switching extension P: partial
+// decryption only ever multiplies the share against the ciphertext's c1,
+// which lives in R_Q).
+func SplitSecretKey(sk *rlwe.SecretKey, q []uint64, th Threshold) (*SetupResult, error) {
+	if err := th.validate(); err != nil {
+		return nil, err
+	}
+
+	coeffs, err := secretKeyCoeffs(sk, len(q))
+	if err != nil {
+		return nil, fmt.Errorf("mpc: SplitSecretKey failed to read sk coefficients: %v", err)
+	}
+
+	shares := make([]*Share, th.N)
+	for p := 0; p < th.N; p++ {
+		shares[p] = &Share{PartyIndex: p + 1, Coeffs: make([][]uint64, len(q))}
+		for limb := range q {
+			shares[p].Coeffs[limb] = make([]uint64, len(coeffs[limb]))
+		}
+	}
+
+	points := make([][][]uint64, len(q))
+	for limb, mod := range q {
+		g := feldmanGenerator(mod)
+		points[limb] = make([][]uint64, len(coeffs[limb]))
+
+		for c, secretCoeff := range coeffs[limb] {
+			// polyCoeffs[0] is the shared secret itself; polyCoeffs[1:] are
+			// fresh randomness, one fewer than the threshold so the
+			// polynomial has degree T-1.
+			polyCoeffs, err := randomPolynomial(secretCoeff, mod, th.T)
+			if err != nil {
+				return nil, fmt.Errorf("mpc: SplitSecretKey failed sampling polynomial for limb %d coeff %d: %v", limb, c, err)
+			}
+
+			points[limb][c] = make([]uint64, th.T)
+			for k, a := range polyCoeffs {
+				points[limb][c][k] = modPow(g, a, mod)
+			}
+
+			for p := 0; p < th.N; p++ {
+				shares[p].Coeffs[limb][c] = evalPolynomial(polyCoeffs, uint64(p+1), mod)
+			}
+		}
+	}
+
+	return &SetupResult{
+		Shares:      shares,
+		Commitments: &Commitments{Threshold: th, Moduli: append([]uint64{}, q...), Points: points},
+	}, nil
+}
+
+// VerifyShare checks share against the dealer's Feldman commitments without
+// learning the secret: for every coefficient, g^(share value) must equal the
+// product of commitments[k]^(partyIndex^k), which holds iff share is really
+// f(partyIndex) for the committed polynomial f. Returns the first mismatch
+// found as an error; a nil return means the share can be trusted in
+// PartialDecrypt.
+func VerifyShare(commitments *Commitments, share *Share) error {
+	if len(share.Coeffs) != len(commitments.Moduli) {
+		return fmt.Errorf("mpc: VerifyShare limb count mismatch: share has %d, commitments have %d", len(share.Coeffs), len(commitments.Moduli))
+	}
+
+	for limb, mod := range commitments.Moduli {
+		g := feldmanGenerator(mod)
+		limbCoeffs := share.Coeffs[limb]
+		limbPoints := commitments.Points[limb]
+		if len(limbCoeffs) != len(limbPoints) {
+			return fmt.Errorf("mpc: VerifyShare coefficient count mismatch at limb %d: share has %d, commitments have %d", limb, len(limbCoeffs), len(limbPoints))
+		}
+
+		for c, value := range limbCoeffs {
+			lhs := modPow(g, value, mod)
+			rhs := feldmanExpected(limbPoints[c], uint64(share.PartyIndex), mod)
+			if lhs != rhs {
+				return fmt.Errorf("mpc: VerifyShare failed for party %d at limb %d coeff %d: share does not match published commitments (corrupted or tampered share)", share.PartyIndex, limb, c)
+			}
+		}
+	}
+	return nil
+}
+
+// feldmanExpected computes prod_k commitments[k]^(x^k) mod m, i.e. the
+// right-hand side of the Feldman verification equation evaluated at x.
+func feldmanExpected(commitmentPoints []uint64, x, m uint64) uint64 {
+	result := uint64(1)
+	xPow := uint64(1)
+	for _, ck := range commitmentPoints {
+		result = mulMod(result, modPow(ck, xPow, m), m)
+		xPow = mulMod(xPow, x, m)
+	}
+	return result
+}
+
+// randomPolynomial samples th-1 uniformly random coefficients mod m and
+// prepends secret, giving the th coefficients of a degree-(th-1) polynomial
+// with f(0) = secret.
+func randomPolynomial(secret, m uint64, th int) ([]uint64, error) {
+	coeffs := make([]uint64, th)
+	coeffs[0] = secret % m
+	for k := 1; k < th; k++ {
+		v, err := randUint64Mod(m)
+		if err != nil {
+			return nil, err
+		}
+		coeffs[k] = v
+	}
+	return coeffs, nil
+}
+
+// evalPolynomial computes f(x) mod m via Horner's method.
+func evalPolynomial(coeffs []uint64, x, m uint64) uint64 {
+	result := uint64(0)
+	for k := len(coeffs) - 1; k >= 0; k-- {
+		result = addMod(mulMod(result, x, m), coeffs[k], m)
+	}
+	return result
+}
+
+func randUint64Mod(m uint64) (uint64, error) {
+	n, err := rand.Int(rand.Reader, new(big.Int).SetUint64(m))
+	if err != nil {
+		return 0, err
+	}
+	return n.Uint64(), nil
+}
+
+// feldmanGenerator derives a fixed, deterministic base for the Feldman
+// commitment group Z_m^* from m itself: every party computes the same base
+// independently, so it never needs to travel over the wire alongside the
+// commitments. m is one of CKKS' NTT-friendly RNS primes, so it is prime and
+// Z_m^* is cyclic; g is picked as the smallest candidate that is a
+// quadratic non-residue, which rules out g generating only the (much
+// smaller) subgroup of squares.
+func feldmanGenerator(m uint64) uint64 {
+	for g := uint64(2); g < m; g++ {
+		if modPow(g, (m-1)/2, m) != 1 {
+			return g
+		}
+	}
+	return 2
+}
+
+func mulMod(a, b, m uint64) uint64 {
+	return new(big.Int).Mod(new(big.Int).Mul(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b)), new(big.Int).SetUint64(m)).Uint64()
+}
+
+func addMod(a, b, m uint64) uint64 {
+	s := a + b
+	if s >= m {
+		s -= m
+	}
+	return s
+}
+
+func modPow(base, exp, m uint64) uint64 {
+	return new(big.Int).Exp(new(big.Int).SetUint64(base), new(big.Int).SetUint64(exp), new(big.Int).SetUint64(m)).Uint64()
+}
+
+// secretKeyCoeffs extracts sk's coefficients under the ciphertext modulus
+// chain, one []uint64 per RNS limb (0..numLimbs-1), in the same NTT-domain
+// representation the evaluator already multiplies ciphertexts in — so a
+// reconstructed share is directly usable against a ciphertext's c1 without
+// any domain conversion.
+func secretKeyCoeffs(sk *rlwe.SecretKey, numLimbs int) ([][]uint64, error) {
+	if sk == nil || sk.Value.Q == nil {
+		return nil, fmt.Errorf("secret key has no Q-ring value")
+	}
+	if len(sk.Value.Q.Coeffs) < numLimbs {
+		return nil, fmt.Errorf("secret key has %d limbs, need %d", len(sk.Value.Q.Coeffs), numLimbs)
+	}
+
+	out := make([][]uint64, numLimbs)
+	for limb := 0; limb < numLimbs; limb++ {
+		out[limb] = append([]uint64{}, sk.Value.Q.Coeffs[limb]...)
+	}
+	return out, nil
+}
+
+// ModuliChain reads off the first numLimbs RNS primes of params' ciphertext
+// modulus, matching the limb ordering SplitSecretKey and secretKeyCoeffs
+// assume.
+func ModuliChain(params ckks.Parameters, numLimbs int) []uint64 {
+	return params.RingQ().ModuliChain()[:numLimbs]
+}
@@ -0,0 +1,115 @@
+// Package queue defines the message-queue transport cmd/worker and
+// internal/server's async inference routes use to decouple CKKS evaluation
+// from an HTTP request's lifetime: a client (or internal/server, on its
+// behalf) publishes an InferenceRequestMessage onto RequestTopic, a worker
+// scores it and publishes an InferenceResultMessage back to the message's
+// own reply topic. Publisher/Subscriber are backed by NATS (NATSQueue)
+// here the same way internal/keystore is backed by AES-GCM — a concrete
+// implementation behind a narrow interface other packages code against.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// RequestTopic is where cmd/worker subscribes and internal/server's async
+// routes publish.
+const RequestTopic = "credit.inference.requests"
+
+// ReplyTopicPrefix namespaces each request's own reply topic so concurrent
+// requests' results never cross streams.
+const ReplyTopicPrefix = "credit.inference.replies."
+
+// InferenceRequestMessage is one request_id's work: either a single
+// applicant's already-encrypted feature vector (EncryptedFeatures) or a
+// packed feature matrix (EncryptedVector, one ciphertext per feature, see
+// app.InferCreditScoreBatch), the evaluation keys needed to score it, and
+// where to publish the result.
+type InferenceRequestMessage struct {
+	RequestID          string   `json:"request_id"`
+	EncryptedFeatures  []byte   `json:"encrypted_features,omitempty"`
+	EncryptedVector    [][]byte `json:"encrypted_vector,omitempty"`
+	NumApplicants      int      `json:"num_applicants,omitempty"`
+	RelinearizationKey []byte   `json:"rlk"`
+	GaloisKeys         [][]byte `json:"galois_keys"`
+	ReplyTopic         string   `json:"reply_topic"`
+}
+
+// TimingBreakdown is how long InferenceResultMessage's worker spent
+// decoding the request's keys versus actually evaluating the circuit.
+type TimingBreakdown struct {
+	DecodeKeysMs float64 `json:"decode_keys_ms"`
+	InferenceMs  float64 `json:"inference_ms"`
+	TotalMs      float64 `json:"total_ms"`
+}
+
+// InferenceResultMessage is a worker's reply to one request_id:
+// EncryptedScore on success, Error instead on failure.
+type InferenceResultMessage struct {
+	RequestID       string          `json:"request_id"`
+	EncryptedScore  []byte          `json:"encrypted_score,omitempty"`
+	TimingBreakdown TimingBreakdown `json:"timing_breakdown"`
+	Error           string          `json:"error,omitempty"`
+}
+
+// Publisher publishes a JSON-encoded message to topic.
+type Publisher interface {
+	Publish(topic string, v interface{}) error
+}
+
+// Subscriber delivers every message published to topic to handler, until
+// ctx is canceled.
+type Subscriber interface {
+	Subscribe(ctx context.Context, topic string, handler func([]byte)) error
+}
+
+// NATSQueue is a Publisher and Subscriber backed by a single NATS
+// connection.
+type NATSQueue struct {
+	conn *nats.Conn
+}
+
+// Dial connects to the NATS server at url.
+func Dial(url string) (*NATSQueue, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("queue: connect to %s: %v", url, err)
+	}
+	return &NATSQueue{conn: conn}, nil
+}
+
+// Close drains and closes the underlying connection.
+func (q *NATSQueue) Close() {
+	q.conn.Close()
+}
+
+// Publish JSON-encodes v and publishes it to topic.
+func (q *NATSQueue) Publish(topic string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("queue: marshal message for %s: %v", topic, err)
+	}
+	return q.conn.Publish(topic, data)
+}
+
+// Subscribe registers handler for every message published to topic.
+// Subscribe returns once the subscription is active; the subscription is
+// torn down when ctx is canceled.
+func (q *NATSQueue) Subscribe(ctx context.Context, topic string, handler func([]byte)) error {
+	sub, err := q.conn.Subscribe(topic, func(msg *nats.Msg) {
+		handler(msg.Data)
+	})
+	if err != nil {
+		return fmt.Errorf("queue: subscribe to %s: %v", topic, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		sub.Unsubscribe()
+	}()
+	return nil
+}
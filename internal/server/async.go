@@ -0,0 +1,165 @@
+// async.go adds the asynchronous counterpart to this package's synchronous
+// /api/v1/score* routes: POST /api/v1/inference/async enqueues a request
+// onto internal/queue's request topic and returns immediately with a
+// request_id, and GET /api/v1/inference/async/{id} polls for cmd/worker's
+// published result, so a caller isn't pinned to an HTTP connection for
+// however long CKKS evaluation takes under load.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"ckks-credit/internal/queue"
+)
+
+// AsyncResult is one inference-async request's current state: "pending"
+// until cmd/worker's reply arrives, then "done" with either a score or an
+// error.
+type AsyncResult struct {
+	Status         string `json:"status"`
+	EncryptedScore []byte `json:"encrypted_score,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// AsyncCoordinator enqueues inference-async requests onto a
+// queue.Publisher and tracks their results as queue.InferenceResultMessage
+// replies arrive on each request's own reply topic.
+type AsyncCoordinator struct {
+	pub queue.Publisher
+	sub queue.Subscriber
+
+	mu      sync.Mutex
+	results map[string]*AsyncResult
+}
+
+// NewAsyncCoordinator builds a coordinator that publishes requests onto pub
+// and listens for replies via sub.
+func NewAsyncCoordinator(pub queue.Publisher, sub queue.Subscriber) *AsyncCoordinator {
+	return &AsyncCoordinator{pub: pub, sub: sub, results: make(map[string]*AsyncResult)}
+}
+
+// Enqueue assigns req a fresh request ID and reply topic, subscribes to
+// that reply topic, then publishes req onto queue.RequestTopic. Subscribing
+// before publishing means a fast worker can't reply before this
+// coordinator is listening for it.
+func (c *AsyncCoordinator) Enqueue(ctx context.Context, req queue.InferenceRequestMessage) (string, error) {
+	requestID, err := newRequestID()
+	if err != nil {
+		return "", fmt.Errorf("generate request id: %v", err)
+	}
+	req.RequestID = requestID
+	req.ReplyTopic = queue.ReplyTopicPrefix + requestID
+
+	c.mu.Lock()
+	c.results[requestID] = &AsyncResult{Status: "pending"}
+	c.mu.Unlock()
+
+	if err := c.sub.Subscribe(ctx, req.ReplyTopic, func(data []byte) {
+		c.handleReply(requestID, data)
+	}); err != nil {
+		return "", fmt.Errorf("subscribe to reply topic: %v", err)
+	}
+
+	if err := c.pub.Publish(queue.RequestTopic, req); err != nil {
+		return "", fmt.Errorf("publish request: %v", err)
+	}
+	return requestID, nil
+}
+
+func (c *AsyncCoordinator) handleReply(requestID string, data []byte) {
+	var msg queue.InferenceResultMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		c.setResult(requestID, &AsyncResult{Status: "done", Error: fmt.Sprintf("decode result: %v", err)})
+		return
+	}
+	c.setResult(requestID, &AsyncResult{Status: "done", EncryptedScore: msg.EncryptedScore, Error: msg.Error})
+}
+
+func (c *AsyncCoordinator) setResult(requestID string, result *AsyncResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[requestID] = result
+}
+
+// Result returns requestID's current state, or ok=false if requestID was
+// never enqueued.
+func (c *AsyncCoordinator) Result(requestID string) (*AsyncResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.results[requestID]
+	return result, ok
+}
+
+func newRequestID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// inferenceAsyncHandler handles POST /api/v1/inference/async: the body is a
+// JSON queue.InferenceRequestMessage (request_id and reply_topic are
+// ignored if set; Enqueue assigns its own), and the response is
+// {"request_id": "..."}.
+func inferenceAsyncHandler(coordinator *AsyncCoordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req queue.InferenceRequestMessage
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		requestID, err := coordinator.Enqueue(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"request_id": requestID})
+	}
+}
+
+// inferenceResultHandler handles GET /api/v1/inference/async/{id}.
+func inferenceResultHandler(coordinator *AsyncCoordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		requestID := strings.TrimPrefix(r.URL.Path, "/api/v1/inference/async/")
+		if requestID == "" {
+			http.Error(w, "missing request id", http.StatusBadRequest)
+			return
+		}
+
+		result, ok := coordinator.Result(requestID)
+		if !ok {
+			http.Error(w, "unknown request id", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// RegisterAsyncRoutes wires the async inference endpoints onto mux.
+func RegisterAsyncRoutes(mux *http.ServeMux, coordinator *AsyncCoordinator) {
+	mux.HandleFunc("/api/v1/inference/async", inferenceAsyncHandler(coordinator))
+	mux.HandleFunc("/api/v1/inference/async/", inferenceResultHandler(coordinator))
+}
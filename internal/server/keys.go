@@ -0,0 +1,87 @@
+// keys.go exposes internal/keystore over HTTP: clients pin a request to a
+// specific key version via the X-Key-Version header on /api/v1/score/*
+// (see ResolveKeyVersion), and an admin can mint a new version via POST
+// /api/v1/keys/rotate, gated on the X-Admin-Token header so an anonymous
+// caller can't force rotations on their own.
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"ckks-credit/internal/keystore"
+)
+
+// AdminTokenHeader is the header POST /api/v1/keys/rotate (and any future
+// admin endpoint under this package) requires, checked against the token
+// RegisterKeyRoutes was given.
+const AdminTokenHeader = "X-Admin-Token"
+
+// KeyVersionHeader is the header clients set to pin a /api/v1/score/*
+// request to a specific key version, e.g. when decrypting a ciphertext
+// cached from before a rotation. Requests without it get the latest version.
+const KeyVersionHeader = "X-Key-Version"
+
+// ResolveKeyVersion reads r's X-Key-Version header, if any, and looks up the
+// matching KeySet from store; absent the header it returns the latest
+// version. Handlers under /api/v1/score/* call this before encrypting or
+// decrypting so they operate under the key version the client expects.
+func ResolveKeyVersion(r *http.Request, store *keystore.Store) (*keystore.KeySet, error) {
+	raw := r.Header.Get(KeyVersionHeader)
+	if raw == "" {
+		return store.Latest()
+	}
+
+	version, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s header %q: %v", KeyVersionHeader, raw, err)
+	}
+	return store.Get(version)
+}
+
+// keysRotateHandler handles POST /api/v1/keys/rotate: generates a new key
+// set, persists it, and reports the version and timestamp it was tagged
+// with. Previous versions remain available for the keystore's grace period.
+// adminToken must be non-empty; a caller that doesn't present it back via
+// X-Admin-Token (compared in constant time, to avoid leaking it through a
+// timing side channel) is rejected before store.Rotate() is ever called.
+func keysRotateHandler(store *keystore.Store, adminToken string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(AdminTokenHeader)), []byte(adminToken)) != 1 {
+			http.Error(w, "missing or invalid "+AdminTokenHeader, http.StatusUnauthorized)
+			return
+		}
+
+		ks, err := store.Rotate()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"version":   ks.Version,
+			"createdAt": ks.CreatedAt,
+		})
+	}
+}
+
+// RegisterKeyRoutes wires the key rotation admin endpoint onto mux.
+// adminToken is the shared secret callers must present via X-Admin-Token;
+// it must be non-empty, since an empty adminToken combined with a caller
+// that also sends no header would otherwise compare equal.
+func RegisterKeyRoutes(mux *http.ServeMux, store *keystore.Store, adminToken string) error {
+	if adminToken == "" {
+		return fmt.Errorf("server: RegisterKeyRoutes requires a non-empty adminToken")
+	}
+	mux.HandleFunc("/api/v1/keys/rotate", keysRotateHandler(store, adminToken))
+	return nil
+}
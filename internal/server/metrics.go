@@ -0,0 +1,67 @@
+// metrics.go exposes internal/crypto.Diagnostics over a Prometheus-scrapeable
+// GET /metrics, so an operator's existing Prometheus setup picks up
+// calibrated per-stage latency, ciphertext size, remaining depth, and
+// precision-loss numbers instead of reading them out of a benchmark
+// binary's stdout.
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"ckks-credit/internal/crypto"
+)
+
+// MetricsSource is what metricsHandler re-runs on every scrape: a
+// Diagnostics wired to the deployment's live KeyManager and evaluator, the
+// workload to drive it with, and how many repetitions per applicant.
+type MetricsSource struct {
+	Diagnostics *crypto.Diagnostics
+	Workloads   []crypto.Workload
+	Reps        int
+}
+
+// metricsHandler handles GET /metrics: it runs src's workload fresh on
+// every scrape and renders the resulting Report in Prometheus text
+// exposition format.
+func metricsHandler(src *MetricsSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := src.Diagnostics.Run(src.Workloads, src.Reps)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("diagnostics run failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeStageMetric(w, "ckks_encode_ms", report.Encode)
+		writeStageMetric(w, "ckks_encrypt_ms", report.Encrypt)
+		writeStageMetric(w, "ckks_eval_ms", report.Eval)
+		writeStageMetric(w, "ckks_decrypt_ms", report.Decrypt)
+
+		writeGauge(w, "ckks_ciphertext_bytes", "Marshalled size of the last evaluated ciphertext.", float64(report.CiphertextBytes))
+		writeGauge(w, "ckks_remaining_levels", "Multiplicative depth left before the modulus chain is exhausted.", float64(report.RemainingLevels))
+		writeGauge(w, "ckks_precision_loss_avg", "Average |decoded - cleartext| across the workload.", report.PrecisionLossAvg)
+		writeGauge(w, "ckks_precision_loss_max", "Maximum |decoded - cleartext| across the workload.", report.PrecisionLossMax)
+	}
+}
+
+// writeStageMetric renders one pipeline stage's mean/stddev/p95 as three
+// gauges sharing name's prefix.
+func writeStageMetric(w http.ResponseWriter, name string, s crypto.StageStats) {
+	fmt.Fprintf(w, "# HELP %s_mean Mean latency in milliseconds.\n", name)
+	fmt.Fprintf(w, "# TYPE %s_mean gauge\n%s_mean %g\n", name, name, s.Mean)
+	fmt.Fprintf(w, "# HELP %s_stddev Population stddev latency in milliseconds.\n", name)
+	fmt.Fprintf(w, "# TYPE %s_stddev gauge\n%s_stddev %g\n", name, name, s.StdDev)
+	fmt.Fprintf(w, "# HELP %s_p95 p95 latency in milliseconds.\n", name)
+	fmt.Fprintf(w, "# TYPE %s_p95 gauge\n%s_p95 %g\n", name, name, s.P95)
+}
+
+func writeGauge(w http.ResponseWriter, name, help string, value float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n%s %g\n", name, name, value)
+}
+
+// RegisterMetricsRoutes wires GET /metrics onto mux.
+func RegisterMetricsRoutes(mux *http.ServeMux, src *MetricsSource) {
+	mux.HandleFunc("/metrics", metricsHandler(src))
+}
@@ -0,0 +1,295 @@
+// Package server exposes CreditScoringService's inference pipeline over
+// HTTP. mpc.go adds the threshold-decryption endpoints: instead of a single
+// party holding the CKKS secret key, a session collects partial decryptions
+// from t-of-n parties (see internal/mpc) and only combines them once enough
+// partials have arrived, or fails the session out once partialTimeout has
+// passed with too few. A party fetches the session's ciphertext and
+// commitments via GET /api/v1/mpc/session/{id}, runs mpc.VerifyShare and
+// mpc.PartialDecrypt itself against its own locally-held Share, and POSTs
+// only the resulting PartialDecryption to /api/v1/mpc/partial — its raw
+// sk_i share never travels over HTTP, let alone reaches this coordinator.
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+
+	"ckks-credit/internal/mpc"
+)
+
+// partialTimeout bounds how long MPCCoordinator waits for a session to
+// collect its threshold before /api/v1/mpc/combine gives up on missing
+// parties rather than blocking the caller indefinitely.
+const partialTimeout = 30 * time.Second
+
+// mpcSession tracks one in-flight threshold decryption: the ciphertext being
+// decrypted, the commitments parties verify their own shares against
+// locally, and the partial decryptions received so far.
+type mpcSession struct {
+	ct          *rlwe.Ciphertext
+	threshold   mpc.Threshold
+	commitments *mpc.Commitments
+	createdAt   time.Time
+
+	mu       sync.Mutex
+	partials map[int]*mpc.PartialDecryption
+}
+
+// MPCCoordinator holds the sessions backing /api/v1/mpc/partial and
+// /api/v1/mpc/combine. It never itself holds a secret-key share; it only
+// aggregates the partials parties submit and interpolates them once there
+// are enough.
+type MPCCoordinator struct {
+	params ckks.Parameters
+
+	mu       sync.Mutex
+	sessions map[string]*mpcSession
+}
+
+// NewMPCCoordinator builds a coordinator for the given CKKS parameters.
+func NewMPCCoordinator(params ckks.Parameters) *MPCCoordinator {
+	return &MPCCoordinator{params: params, sessions: make(map[string]*mpcSession)}
+}
+
+// OpenSession registers a ciphertext for threshold decryption under
+// sessionID, associating it with the commitments its parties' shares must
+// verify against. It must be called (typically by the same caller that
+// requested the encrypted score) before any party POSTs to
+// /api/v1/mpc/partial for that sessionID.
+func (c *MPCCoordinator) OpenSession(sessionID string, ct *rlwe.Ciphertext, commitments *mpc.Commitments) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions[sessionID] = &mpcSession{
+		ct:          ct,
+		threshold:   commitments.Threshold,
+		commitments: commitments,
+		createdAt:   time.Now(),
+		partials:    make(map[int]*mpc.PartialDecryption),
+	}
+}
+
+func (c *MPCCoordinator) session(sessionID string) (*mpcSession, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("unknown session %q", sessionID)
+	}
+	return s, nil
+}
+
+// sessionInfo is everything a party needs to compute its own partial
+// decryption: the ciphertext being decrypted and the public commitments its
+// share must verify against. Neither reveals anything about any party's
+// secret share.
+type sessionInfo struct {
+	ct          *rlwe.Ciphertext
+	commitments *mpc.Commitments
+}
+
+// Session returns the ciphertext and commitments registered for sessionID,
+// so a party can run mpc.VerifyShare and mpc.PartialDecrypt against its own
+// locally-held Share before ever calling submitPartial.
+func (c *MPCCoordinator) Session(sessionID string) (*sessionInfo, error) {
+	s, err := c.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionInfo{ct: s.ct, commitments: s.commitments}, nil
+}
+
+// submitPartial records a party's already-computed, already-verified partial
+// decryption. The coordinator never sees the share it was derived from — a
+// party runs mpc.VerifyShare and mpc.PartialDecrypt itself, against the
+// Session this sessionID's commitments and ciphertext describe, and submits
+// only the resulting PartialDecryption here. Since the coordinator no longer
+// holds the share, it can't re-verify partial's correctness cryptographically;
+// it only checks partial's shape is consistent with the session's ciphertext,
+// mirroring internal/crypto/threshold.go's level/scale consistency check at
+// combine time. A party submitting a bogus partial still poisons the
+// reconstructed score — same as internal/crypto/threshold.go's CombineShares
+// — and is caught at Combine/DecodeScore time, not here.
+func (c *MPCCoordinator) submitPartial(sessionID string, partial *mpc.PartialDecryption) error {
+	s, err := c.session(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if wantLimbs := s.ct.Level() + 1; len(partial.Coeffs) != wantLimbs {
+		return fmt.Errorf("partial decryption rejected: have %d limbs, want %d", len(partial.Coeffs), wantLimbs)
+	}
+
+	s.mu.Lock()
+	s.partials[partial.PartyIndex] = partial
+	s.mu.Unlock()
+	return nil
+}
+
+// tryCombine returns the decoded score once at least the session's threshold
+// of partials have arrived. Before that, and once partialTimeout has elapsed
+// without reaching it, it reports how many parties are still missing so the
+// caller can decide whether to keep polling or give up.
+func (c *MPCCoordinator) tryCombine(sessionID string) (score float64, ready bool, err error) {
+	s, err := c.session(sessionID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	s.mu.Lock()
+	partials := make([]*mpc.PartialDecryption, 0, len(s.partials))
+	for _, p := range s.partials {
+		partials = append(partials, p)
+	}
+	have := len(partials)
+	timedOut := time.Since(s.createdAt) > partialTimeout
+	s.mu.Unlock()
+
+	if have < s.threshold.T {
+		if timedOut {
+			return 0, false, fmt.Errorf("timed out waiting for parties: have %d partials, need %d", have, s.threshold.T)
+		}
+		return 0, false, nil
+	}
+
+	pt, err := mpc.Combine(c.params, s.ct, partials, s.threshold)
+	if err != nil {
+		return 0, false, fmt.Errorf("combine failed: %v", err)
+	}
+	score, err = mpc.DecodeScore(c.params, pt)
+	if err != nil {
+		return 0, false, err
+	}
+	return score, true, nil
+}
+
+// partialRequest is the body /api/v1/mpc/partial expects: a party submitting
+// the partial decryption it already computed locally (via mpc.VerifyShare
+// and mpc.PartialDecrypt against the ciphertext and commitments fetched from
+// GET /api/v1/mpc/session/{id}) for the coordinator to fold in. The party's
+// raw Share — and its underlying sk_i — never appears in this request.
+type partialRequest struct {
+	SessionID  string     `json:"sessionId"`
+	PartyIndex int        `json:"partyIndex"`
+	Coeffs     [][]uint64 `json:"coeffs"`
+}
+
+// combineRequest is the body /api/v1/mpc/combine expects.
+type combineRequest struct {
+	SessionID string `json:"sessionId"`
+}
+
+// sessionResponse is what GET /api/v1/mpc/session/{id} returns: the
+// ciphertext and public commitments a party needs to compute its own partial
+// decryption. Ct is the ciphertext's MarshalBinary encoding, matching how
+// ciphertexts are carried over JSON elsewhere in this repo (e.g.
+// AsyncResult.EncryptedScore); Commitments are safe to publish as-is, since
+// VerifyShare is designed to check a share against them without revealing sk.
+type sessionResponse struct {
+	Ct          []byte           `json:"ct"`
+	Commitments *mpc.Commitments `json:"commitments"`
+}
+
+// mpcSessionHandler handles GET /api/v1/mpc/session/{id}: a party fetches
+// the session's ciphertext and commitments so it can run mpc.VerifyShare and
+// mpc.PartialDecrypt itself before POSTing to /api/v1/mpc/partial.
+func mpcSessionHandler(coordinator *MPCCoordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		sessionID := strings.TrimPrefix(r.URL.Path, "/api/v1/mpc/session/")
+		if sessionID == "" {
+			http.Error(w, "missing session id", http.StatusBadRequest)
+			return
+		}
+
+		info, err := coordinator.Session(sessionID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		ctBytes, err := info.ct.MarshalBinary()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("marshal ciphertext: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sessionResponse{Ct: ctBytes, Commitments: info.commitments})
+	}
+}
+
+// mpcPartialHandler handles POST /api/v1/mpc/partial: one party's
+// already-verified, already-computed partial decryption contribution toward
+// decrypting the session's ciphertext.
+func mpcPartialHandler(coordinator *MPCCoordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req partialRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		partial := &mpc.PartialDecryption{PartyIndex: req.PartyIndex, Coeffs: req.Coeffs}
+		if err := coordinator.submitPartial(req.SessionID, partial); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"accepted": true})
+	}
+}
+
+// mpcCombineHandler handles POST /api/v1/mpc/combine: attempts to
+// reconstruct the session's score from whatever verified partials have
+// arrived so far.
+func mpcCombineHandler(coordinator *MPCCoordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req combineRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		score, ready, err := coordinator.tryCombine(req.SessionID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !ready {
+			json.NewEncoder(w).Encode(map[string]any{"ready": false})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"ready": true, "score": score})
+	}
+}
+
+// RegisterMPCRoutes wires the threshold-decryption endpoints onto mux.
+func RegisterMPCRoutes(mux *http.ServeMux, coordinator *MPCCoordinator) {
+	mux.HandleFunc("/api/v1/mpc/session/", mpcSessionHandler(coordinator))
+	mux.HandleFunc("/api/v1/mpc/partial", mpcPartialHandler(coordinator))
+	mux.HandleFunc("/api/v1/mpc/combine", mpcCombineHandler(coordinator))
+}
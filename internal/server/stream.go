@@ -0,0 +1,228 @@
+// stream.go adds the binary streaming counterpart to this package's JSON
+// API: pkg/client's RegisterKeys posts a session's relinearization and
+// rotation keys once via POST /api/v1/score/stream/keys, and Score then
+// POSTs one ciphertext at a time to /api/v1/score/stream, identified by the
+// X-Session-Id header, instead of base64-encoding everything inside a JSON
+// body and re-uploading the relinearization key on every call.
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+
+	"ckks-credit/internal/app"
+)
+
+// StreamKeyTTL bounds how long a session's evaluation keys stay resident
+// after RegisterKeys before the session is treated as expired, so an
+// abandoned client doesn't pin a multi-MB relinearization key in server
+// memory forever.
+const StreamKeyTTL = 10 * time.Minute
+
+// streamSession is one registered key set: an evaluator built from the
+// client's rlk/rotk, live until expiresAt.
+type streamSession struct {
+	evaluator *ckks.Evaluator
+	expiresAt time.Time
+}
+
+// StreamCoordinator holds the evaluation keys RegisterKeys has stashed per
+// session, so ScoreStream can build a ciphertext's inference without the
+// caller re-uploading its relinearization key on every request.
+type StreamCoordinator struct {
+	params ckks.Parameters
+	scorer *app.CreditScorer
+
+	mu       sync.Mutex
+	sessions map[string]*streamSession
+}
+
+// NewStreamCoordinator builds a coordinator that scores ciphertexts against
+// scorer's weights and bias under params, the same weighted dot product
+// app.InferenceEngine uses for the JSON API.
+func NewStreamCoordinator(params ckks.Parameters, scorer *app.CreditScorer) *StreamCoordinator {
+	return &StreamCoordinator{params: params, scorer: scorer, sessions: make(map[string]*streamSession)}
+}
+
+// register builds an evaluator from rlk/rotk and stashes it under a fresh
+// session ID with a fresh TTL.
+func (c *StreamCoordinator) register(rlk *rlwe.RelinearizationKey, rotk []*rlwe.GaloisKey) (string, error) {
+	sessionID, err := newSessionID()
+	if err != nil {
+		return "", fmt.Errorf("generate session id: %v", err)
+	}
+
+	evk := rlwe.NewMemEvaluationKeySet(rlk, rotk...)
+	evaluator := ckks.NewEvaluator(c.params, evk)
+
+	c.mu.Lock()
+	c.sessions[sessionID] = &streamSession{evaluator: evaluator, expiresAt: time.Now().Add(StreamKeyTTL)}
+	c.mu.Unlock()
+	return sessionID, nil
+}
+
+func (c *StreamCoordinator) session(sessionID string) (*streamSession, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("unknown session %q", sessionID)
+	}
+	if time.Now().After(s.expiresAt) {
+		delete(c.sessions, sessionID)
+		return nil, fmt.Errorf("session %q has expired, call RegisterKeys again", sessionID)
+	}
+	return s, nil
+}
+
+// score runs app.InnerProduct against the session's evaluator and adds the
+// scorer's bias, mirroring app.InferenceEngine.InferCreditScore.
+func (c *StreamCoordinator) score(sessionID string, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	s, err := c.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := c.scorer.Weights()
+	encoder := ckks.NewEncoder(c.params)
+
+	weightValues := make([]complex128, c.params.MaxSlots())
+	for i, w := range weights {
+		weightValues[i] = complex(w, 0)
+	}
+	weightsPt := ckks.NewPlaintext(c.params, ct.Level())
+	if err := encoder.Encode(weightValues, weightsPt); err != nil {
+		return nil, fmt.Errorf("encode weights: %v", err)
+	}
+
+	result, err := app.InnerProduct(s.evaluator, ct, weightsPt, len(weights))
+	if err != nil {
+		return nil, fmt.Errorf("inner product: %v", err)
+	}
+
+	biasValues := make([]complex128, c.params.MaxSlots())
+	biasValues[0] = complex(c.scorer.Bias(), 0)
+	biasPt := ckks.NewPlaintext(c.params, result.Level())
+	if err := encoder.Encode(biasValues, biasPt); err != nil {
+		return nil, fmt.Errorf("encode bias: %v", err)
+	}
+	if err := s.evaluator.Add(result, biasPt, result); err != nil {
+		return nil, fmt.Errorf("add bias: %v", err)
+	}
+
+	return result, nil
+}
+
+// RegisterSession is the exported counterpart to register, for callers
+// outside this package (internal/grpcapi's InferenceStream RPC) that
+// already hold deserialized rlk/rotk rather than posting them over HTTP.
+func (c *StreamCoordinator) RegisterSession(rlk *rlwe.RelinearizationKey, rotk []*rlwe.GaloisKey) (string, error) {
+	return c.register(rlk, rotk)
+}
+
+// ScoreSession is the exported counterpart to score.
+func (c *StreamCoordinator) ScoreSession(sessionID string, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	return c.score(sessionID, ct)
+}
+
+func newSessionID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// registerKeysHandler handles POST /api/v1/score/stream/keys: the body is
+// rlk followed by X-Rotation-Count Galois keys, each written back-to-back
+// via WriteTo/ReadFrom rather than framed as separate multipart parts. The
+// response body is the new session ID.
+func registerKeysHandler(coordinator *StreamCoordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		rotCount, err := strconv.Atoi(r.Header.Get("X-Rotation-Count"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid X-Rotation-Count: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		rlk := new(rlwe.RelinearizationKey)
+		if _, err := rlk.ReadFrom(r.Body); err != nil {
+			http.Error(w, fmt.Sprintf("read relinearization key: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		rotk := make([]*rlwe.GaloisKey, rotCount)
+		for i := range rotk {
+			gk := new(rlwe.GaloisKey)
+			if _, err := gk.ReadFrom(r.Body); err != nil {
+				http.Error(w, fmt.Sprintf("read rotation key %d: %v", i, err), http.StatusBadRequest)
+				return
+			}
+			rotk[i] = gk
+		}
+
+		sessionID, err := coordinator.register(rlk, rotk)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, sessionID)
+	}
+}
+
+// scoreStreamHandler handles POST /api/v1/score/stream: the body is one
+// ciphertext's raw MarshalBinary bytes, scored under the session named by
+// X-Session-Id, with the encrypted result written back the same way.
+func scoreStreamHandler(coordinator *StreamCoordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		sessionID := r.Header.Get("X-Session-Id")
+		if sessionID == "" {
+			http.Error(w, "missing X-Session-Id header", http.StatusBadRequest)
+			return
+		}
+
+		ct := new(rlwe.Ciphertext)
+		if _, err := ct.ReadFrom(r.Body); err != nil {
+			http.Error(w, fmt.Sprintf("read ciphertext: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		result, err := coordinator.score(sessionID, ct)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.Itoa(result.BinarySize()))
+		result.WriteTo(w)
+	}
+}
+
+// RegisterStreamRoutes wires the binary streaming scoring endpoints onto mux.
+func RegisterStreamRoutes(mux *http.ServeMux, coordinator *StreamCoordinator) {
+	mux.HandleFunc("/api/v1/score/stream/keys", registerKeysHandler(coordinator))
+	mux.HandleFunc("/api/v1/score/stream", scoreStreamHandler(coordinator))
+}
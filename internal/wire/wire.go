@@ -0,0 +1,261 @@
+// Package wire defines versioned binary envelopes for the CKKS values that
+// cross the client/server boundary in a real "the bank encrypts, the
+// scoring provider evaluates" deployment: ciphertexts, evaluation keys, and
+// the parameter literal itself. Every envelope wraps Lattigo's own
+// MarshalBinary payload in a small header (magic, format version, a hash of
+// the CKKS parameters it was produced under, and a ciphertext's level), so
+// a receiver can reject a mismatched-parameter or over-level payload with a
+// typed error before it ever reaches Lattigo's parser — a client on the
+// wrong parameter set gets a clear rejection instead of a silent noise
+// blow-up deep inside evaluation.
+package wire
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// magic identifies an envelope produced by this package, so Unmarshal can
+// reject a raw Lattigo payload (or an unrelated blob) with a clear error
+// instead of Lattigo's own parser failing confusingly deep inside.
+const magic uint32 = 0x434b4b53 // "CKKS"
+
+// version is this envelope format's revision. Unmarshal rejects anything
+// else rather than guessing at a layout change.
+const version uint16 = 1
+
+// kind distinguishes which CKKS value an envelope carries, so e.g. a public
+// key byte slice can't be mistaken for a ciphertext.
+type kind uint8
+
+const (
+	kindCiphertext kind = iota + 1
+	kindPublicKey
+	kindRelinearizationKey
+	kindParametersLiteral
+)
+
+// headerSize is magic(4) + version(2) + kind(1) + paramHash(8) + level(4).
+const headerSize = 4 + 2 + 1 + 8 + 4
+
+// ErrBadMagic is returned when data doesn't start with this package's magic
+// number — it isn't a wire envelope at all.
+type ErrBadMagic struct{}
+
+func (ErrBadMagic) Error() string { return "wire: not a valid envelope (bad magic)" }
+
+// ErrUnsupportedVersion is returned when an envelope's version is newer or
+// older than this build understands.
+type ErrUnsupportedVersion struct{ Got uint16 }
+
+func (e ErrUnsupportedVersion) Error() string {
+	return fmt.Sprintf("wire: unsupported envelope version %d (this build supports %d)", e.Got, version)
+}
+
+// ErrKindMismatch is returned when an envelope's kind doesn't match the
+// Unmarshal function it was passed to.
+type ErrKindMismatch struct{ Want, Got kind }
+
+func (e ErrKindMismatch) Error() string {
+	return fmt.Sprintf("wire: expected envelope kind %d, got %d", e.Want, e.Got)
+}
+
+// ErrParamMismatch is returned when an envelope's CKKS parameter hash
+// doesn't match the params it's being unmarshalled against.
+type ErrParamMismatch struct{ Want, Got uint64 }
+
+func (e ErrParamMismatch) Error() string {
+	return fmt.Sprintf("wire: parameter mismatch: envelope hash %x, expected %x", e.Got, e.Want)
+}
+
+// ErrLevelTooHigh is returned when a ciphertext envelope's level exceeds
+// params.MaxLevel() for the parameters it's matched against.
+type ErrLevelTooHigh struct{ Level, MaxLevel int }
+
+func (e ErrLevelTooHigh) Error() string {
+	return fmt.Sprintf("wire: ciphertext level %d exceeds params.MaxLevel() %d", e.Level, e.MaxLevel)
+}
+
+// ParamHash returns a short, process-stable fingerprint of params'
+// LogN/LogQ/LogP/LogDefaultScale, so two parties can confirm they're
+// matched without shipping the full parameter literal on every RPC.
+func ParamHash(params ckks.Parameters) uint64 {
+	h := fnv.New64a()
+	write := func(v int) {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], uint64(int64(v)))
+		h.Write(buf[:])
+	}
+
+	write(params.LogN())
+	for _, q := range params.LogQ() {
+		write(q)
+	}
+	for _, p := range params.LogP() {
+		write(p)
+	}
+	write(params.LogDefaultScale())
+	return h.Sum64()
+}
+
+func writeHeader(k kind, paramHash uint64, level int) []byte {
+	buf := make([]byte, headerSize)
+	binary.BigEndian.PutUint32(buf[0:4], magic)
+	binary.BigEndian.PutUint16(buf[4:6], version)
+	buf[6] = byte(k)
+	binary.BigEndian.PutUint64(buf[7:15], paramHash)
+	binary.BigEndian.PutUint32(buf[15:19], uint32(level))
+	return buf
+}
+
+func readHeader(data []byte) (k kind, paramHash uint64, level int, err error) {
+	if len(data) < headerSize {
+		return 0, 0, 0, fmt.Errorf("wire: envelope too short (%d bytes, want at least %d)", len(data), headerSize)
+	}
+	if binary.BigEndian.Uint32(data[0:4]) != magic {
+		return 0, 0, 0, ErrBadMagic{}
+	}
+	if v := binary.BigEndian.Uint16(data[4:6]); v != version {
+		return 0, 0, 0, ErrUnsupportedVersion{Got: v}
+	}
+	k = kind(data[6])
+	paramHash = binary.BigEndian.Uint64(data[7:15])
+	level = int(binary.BigEndian.Uint32(data[15:19]))
+	return k, paramHash, level, nil
+}
+
+// MarshalCiphertext wraps ct's Lattigo-native encoding in a versioned
+// envelope tagged with params' hash and ct's level.
+func MarshalCiphertext(ct *rlwe.Ciphertext, params ckks.Parameters) ([]byte, error) {
+	payload, err := ct.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("wire: marshal ciphertext: %v", err)
+	}
+	return append(writeHeader(kindCiphertext, ParamHash(params), ct.Level()), payload...), nil
+}
+
+// UnmarshalCiphertext parses an envelope produced by MarshalCiphertext,
+// rejecting it with a typed error if it was encoded under different CKKS
+// parameters (ErrParamMismatch) or claims a level above params.MaxLevel()
+// (ErrLevelTooHigh), instead of letting a mismatched ciphertext reach
+// evaluation and silently blow its noise budget.
+func UnmarshalCiphertext(data []byte, params ckks.Parameters) (*rlwe.Ciphertext, error) {
+	k, paramHash, level, err := readHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if k != kindCiphertext {
+		return nil, ErrKindMismatch{Want: kindCiphertext, Got: k}
+	}
+	if want := ParamHash(params); paramHash != want {
+		return nil, ErrParamMismatch{Want: want, Got: paramHash}
+	}
+	if level > params.MaxLevel() {
+		return nil, ErrLevelTooHigh{Level: level, MaxLevel: params.MaxLevel()}
+	}
+
+	ct := new(rlwe.Ciphertext)
+	if err := ct.UnmarshalBinary(data[headerSize:]); err != nil {
+		return nil, fmt.Errorf("wire: unmarshal ciphertext: %v", err)
+	}
+	return ct, nil
+}
+
+// MarshalPublicKey wraps pk's Lattigo-native encoding in a versioned
+// envelope tagged with params' hash.
+func MarshalPublicKey(pk *rlwe.PublicKey, params ckks.Parameters) ([]byte, error) {
+	payload, err := pk.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("wire: marshal public key: %v", err)
+	}
+	return append(writeHeader(kindPublicKey, ParamHash(params), 0), payload...), nil
+}
+
+// UnmarshalPublicKey parses an envelope produced by MarshalPublicKey,
+// rejecting a parameter mismatch the same way UnmarshalCiphertext does.
+func UnmarshalPublicKey(data []byte, params ckks.Parameters) (*rlwe.PublicKey, error) {
+	k, paramHash, _, err := readHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if k != kindPublicKey {
+		return nil, ErrKindMismatch{Want: kindPublicKey, Got: k}
+	}
+	if want := ParamHash(params); paramHash != want {
+		return nil, ErrParamMismatch{Want: want, Got: paramHash}
+	}
+
+	pk := new(rlwe.PublicKey)
+	if err := pk.UnmarshalBinary(data[headerSize:]); err != nil {
+		return nil, fmt.Errorf("wire: unmarshal public key: %v", err)
+	}
+	return pk, nil
+}
+
+// MarshalRelinearizationKey wraps rlk's Lattigo-native encoding in a
+// versioned envelope tagged with params' hash.
+func MarshalRelinearizationKey(rlk *rlwe.RelinearizationKey, params ckks.Parameters) ([]byte, error) {
+	payload, err := rlk.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("wire: marshal relinearization key: %v", err)
+	}
+	return append(writeHeader(kindRelinearizationKey, ParamHash(params), 0), payload...), nil
+}
+
+// UnmarshalRelinearizationKey parses an envelope produced by
+// MarshalRelinearizationKey, rejecting a parameter mismatch the same way
+// UnmarshalCiphertext does.
+func UnmarshalRelinearizationKey(data []byte, params ckks.Parameters) (*rlwe.RelinearizationKey, error) {
+	k, paramHash, _, err := readHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if k != kindRelinearizationKey {
+		return nil, ErrKindMismatch{Want: kindRelinearizationKey, Got: k}
+	}
+	if want := ParamHash(params); paramHash != want {
+		return nil, ErrParamMismatch{Want: want, Got: paramHash}
+	}
+
+	rlk := new(rlwe.RelinearizationKey)
+	if err := rlk.UnmarshalBinary(data[headerSize:]); err != nil {
+		return nil, fmt.Errorf("wire: unmarshal relinearization key: %v", err)
+	}
+	return rlk, nil
+}
+
+// MarshalParametersLiteral wraps a ckks.ParametersLiteral as JSON inside
+// the same envelope header (paramHash is left 0: the literal being
+// transmitted is what establishes the hash, not something to check it
+// against). GetParameters-style RPCs use this so a client can construct its
+// own ckks.Parameters from exactly the literal the server scores with.
+func MarshalParametersLiteral(lit ckks.ParametersLiteral) ([]byte, error) {
+	payload, err := json.Marshal(lit)
+	if err != nil {
+		return nil, fmt.Errorf("wire: marshal parameters literal: %v", err)
+	}
+	return append(writeHeader(kindParametersLiteral, 0, 0), payload...), nil
+}
+
+// UnmarshalParametersLiteral parses an envelope produced by
+// MarshalParametersLiteral.
+func UnmarshalParametersLiteral(data []byte) (ckks.ParametersLiteral, error) {
+	k, _, _, err := readHeader(data)
+	if err != nil {
+		return ckks.ParametersLiteral{}, err
+	}
+	if k != kindParametersLiteral {
+		return ckks.ParametersLiteral{}, ErrKindMismatch{Want: kindParametersLiteral, Got: k}
+	}
+
+	var lit ckks.ParametersLiteral
+	if err := json.Unmarshal(data[headerSize:], &lit); err != nil {
+		return ckks.ParametersLiteral{}, fmt.Errorf("wire: unmarshal parameters literal: %v", err)
+	}
+	return lit, nil
+}
@@ -0,0 +1,115 @@
+// Package batch gives external callers a typed API onto internal/app's
+// block-packed batch scoring: Evaluator packs several applicants' feature
+// vectors into a single ciphertext's slots (internal/app.BatchScorer's
+// stride layout, applicant b at [b*numFeatures, (b+1)*numFeatures)), runs
+// the linear scoring circuit once against the whole ciphertext, and applies
+// app.EncryptedSigmoid's polynomial approximation once more — the
+// polynomial evaluator runs slotwise, so every applicant's block is
+// activated in the same pass. Decryptor then unpacks one score per
+// applicant. InferCreditScore wraps Score as a batch of one so callers
+// migrating off the single-applicant API don't need a second code path.
+package batch
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+
+	"ckks-credit/internal/app"
+)
+
+// Evaluator scores up to MaxBatchSize() applicants per ciphertext.
+type Evaluator struct {
+	scorer      *app.BatchScorer
+	params      ckks.Parameters
+	approxRange app.ApproxRange
+}
+
+// NewEvaluator builds an Evaluator bound to scorer's weights/bias, deriving
+// its evaluation keys from sk the same way app.NewBatchScorer does.
+// approxRange picks which app.EncryptedSigmoid minimax table the activation
+// stage evaluates (see app.DefaultSigmoidRange).
+func NewEvaluator(params ckks.Parameters, scorer *app.CreditScorer, sk *rlwe.SecretKey, approxRange app.ApproxRange) (*Evaluator, error) {
+	batchScorer, err := app.NewBatchScorer(params, scorer, sk)
+	if err != nil {
+		return nil, fmt.Errorf("batch: build scorer: %v", err)
+	}
+	return &Evaluator{scorer: batchScorer, params: params, approxRange: approxRange}, nil
+}
+
+// MaxBatchSize is how many applicants fit in a single ciphertext.
+func (e *Evaluator) MaxBatchSize() int {
+	return e.scorer.ApplicantsPerCiphertext()
+}
+
+// Score encrypts, scores, and sigmoid-activates applicants in chunks of
+// MaxBatchSize(), returning one ciphertext per chunk with every applicant's
+// activated score replicated across its numFeatures-wide block.
+func (e *Evaluator) Score(applicants [][]float64) ([]*rlwe.Ciphertext, error) {
+	linear, err := e.scorer.Score(applicants)
+	if err != nil {
+		return nil, fmt.Errorf("batch: linear pass: %v", err)
+	}
+
+	activated := make([]*rlwe.Ciphertext, len(linear))
+	for i, ct := range linear {
+		result, err := app.EncryptedSigmoid(e.scorer.Evaluator(), e.params, ct, e.approxRange)
+		if err != nil {
+			return nil, fmt.Errorf("batch: sigmoid chunk %d: %v", i, err)
+		}
+		activated[i] = result
+	}
+	return activated, nil
+}
+
+// InferCreditScore scores a single applicant as a batch of one, the same
+// scoring circuit Score uses for any batch size.
+func (e *Evaluator) InferCreditScore(features []float64) (*rlwe.Ciphertext, error) {
+	results, err := e.Score([][]float64{features})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// Decryptor unpacks per-applicant scores out of Evaluator's block-packed
+// ciphertexts.
+type Decryptor struct {
+	params      ckks.Parameters
+	encoder     *ckks.Encoder
+	decryptor   *rlwe.Decryptor
+	numFeatures int
+}
+
+// NewDecryptor builds a Decryptor for numFeatures-wide blocks, decrypting
+// with sk.
+func NewDecryptor(params ckks.Parameters, sk *rlwe.SecretKey, numFeatures int) *Decryptor {
+	return &Decryptor{
+		params:      params,
+		encoder:     ckks.NewEncoder(params),
+		decryptor:   rlwe.NewDecryptor(params, sk),
+		numFeatures: numFeatures,
+	}
+}
+
+// Unpack decrypts ct and returns the numApplicants scores packed into its
+// blocks, one per applicant's numFeatures-wide slot range.
+func (d *Decryptor) Unpack(ct *rlwe.Ciphertext, numApplicants int) ([]float64, error) {
+	maxPerCT := d.params.MaxSlots() / d.numFeatures
+	if numApplicants > maxPerCT {
+		return nil, fmt.Errorf("batch: %d applicants exceeds %d per ciphertext", numApplicants, maxPerCT)
+	}
+
+	pt := d.decryptor.DecryptNew(ct)
+	decoded := make([]float64, d.params.MaxSlots())
+	if err := d.encoder.Decode(pt, decoded); err != nil {
+		return nil, fmt.Errorf("batch: decode: %v", err)
+	}
+
+	scores := make([]float64, numApplicants)
+	for b := 0; b < numApplicants; b++ {
+		scores[b] = decoded[b*d.numFeatures]
+	}
+	return scores, nil
+}
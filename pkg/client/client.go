@@ -0,0 +1,125 @@
+// Package client implements a typed Go client for the binary streaming
+// scoring protocol internal/server's stream.go exposes. The JSON API
+// base64-encodes every ciphertext and requires the relinearization key to
+// be re-uploaded on every request (see docs/archived/baseline/optimized/e2e.go's
+// InferenceRequest, where "RLK Size" is printed as sent once per test even
+// though it goes out with every call); RegisterKeys instead ships the
+// evaluation keys once per session, and Score streams only the raw
+// MarshalBinary bytes of each ciphertext.
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// Client talks to internal/server's /api/v1/score/stream endpoints.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// New builds a Client against baseURL (e.g. "https://localhost:8080").
+// httpClient lets the caller plug in an HTTP/2-configured transport; nil
+// falls back to http.DefaultClient.
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: baseURL, http: httpClient}
+}
+
+// RegisterKeys streams rlk and rotk to the server once and returns the
+// session ID Score must pass on every subsequent call made under this key
+// set. The keys are written back-to-back via WriteTo rather than batched
+// into a []byte first, so a multi-megabyte relinearization key doesn't need
+// to be buffered twice.
+func (c *Client) RegisterKeys(ctx context.Context, rlk *rlwe.RelinearizationKey, rotk []*rlwe.GaloisKey) (string, error) {
+	size := rlk.BinarySize()
+	for _, gk := range rotk {
+		size += gk.BinarySize()
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		var err error
+		defer func() { pw.CloseWithError(err) }()
+
+		if _, err = rlk.WriteTo(pw); err != nil {
+			return
+		}
+		for _, gk := range rotk {
+			if _, err = gk.WriteTo(pw); err != nil {
+				return
+			}
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/score/stream/keys", pr)
+	if err != nil {
+		return "", fmt.Errorf("client: build register-keys request: %v", err)
+	}
+	req.ContentLength = int64(size)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Rotation-Count", strconv.Itoa(len(rotk)))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("client: register keys: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("client: read register-keys response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("client: server rejected key registration (%d): %s", resp.StatusCode, body)
+	}
+
+	return string(body), nil
+}
+
+// Score streams ct to the server under sessionID and returns the resulting
+// encrypted score. Both directions move raw ciphertext bytes over
+// application/octet-stream instead of base64 inside a JSON envelope, and
+// neither side has to materialize the whole ciphertext in memory: the
+// request body is read straight off ct's WriteTo, and the response is
+// decoded straight off the connection via ReadFrom.
+func (c *Client) Score(ctx context.Context, sessionID string, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := ct.WriteTo(pw)
+		pw.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/score/stream", pr)
+	if err != nil {
+		return nil, fmt.Errorf("client: build score request: %v", err)
+	}
+	req.ContentLength = int64(ct.BinarySize())
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Session-Id", sessionID)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("client: score request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("client: server rejected score request (%d): %s", resp.StatusCode, body)
+	}
+
+	result := new(rlwe.Ciphertext)
+	if _, err := result.ReadFrom(resp.Body); err != nil {
+		return nil, fmt.Errorf("client: read score: %v", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"syscall/js"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/multiparty"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+// keyEnvelopeVersion is bumped whenever encodeKeyEnvelope's wire layout
+// changes, so fheExpandKeys can refuse an envelope it doesn't understand
+// instead of misreading it.
+const keyEnvelopeVersion byte = 1
+
+// compressedKeySeedBytes is the length of the random seed
+// genSeededPublicKeyShare draws for a compressed public key's "a" component.
+const compressedKeySeedBytes = 32
+
+// Key envelope kinds. keyEnvelopeSeededPublicKey drops the public key's "a"
+// component entirely (rebuilt from seed on expand); keyEnvelopeDeflate keeps
+// the key's own marshaled form but DEFLATEs it, for the key types (RLK,
+// Galois keys) whose multi-sample gadget structure isn't exposed as a single
+// reseedable "a" the way a public key's is.
+const (
+	keyEnvelopeSeededPublicKey byte = iota
+	keyEnvelopeDeflate
+)
+
+// encodeKeyEnvelope packs a version byte, a kind byte, and length-prefixed
+// seed/payload blobs (seed is empty for keyEnvelopeDeflate) into the
+// container fheExpandKeys parses back apart.
+func encodeKeyEnvelope(kind byte, seed, payload []byte) []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(keyEnvelopeVersion)
+	buf.WriteByte(kind)
+	writeLenPrefixed(buf, seed)
+	writeLenPrefixed(buf, payload)
+	return buf.Bytes()
+}
+
+func readLenPrefixed(r io.Reader) ([]byte, error) {
+	var length uint32
+	if err := readUint32(r, &length); err != nil {
+		return nil, err
+	}
+	blob := make([]byte, length)
+	if _, err := io.ReadFull(r, blob); err != nil {
+		return nil, fmt.Errorf("failed to read %d-byte blob: %v", length, err)
+	}
+	return blob, nil
+}
+
+func readUint32(r io.Reader, out *uint32) error {
+	var b [4]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return err
+	}
+	*out = uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+	return nil
+}
+
+// decodeKeyEnvelope is encodeKeyEnvelope's inverse.
+func decodeKeyEnvelope(data []byte) (kind byte, seed, payload []byte, err error) {
+	if len(data) < 2 {
+		return 0, nil, nil, fmt.Errorf("key envelope too short")
+	}
+	version, kind := data[0], data[1]
+	if version != keyEnvelopeVersion {
+		return 0, nil, nil, fmt.Errorf("unsupported key envelope version %d", version)
+	}
+
+	r := bytes.NewReader(data[2:])
+	if seed, err = readLenPrefixed(r); err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to read envelope seed: %v", err)
+	}
+	if payload, err = readLenPrefixed(r); err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to read envelope payload: %v", err)
+	}
+	return kind, seed, payload, nil
+}
+
+func deflateCompress(data []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w, err := flate.NewWriter(buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func deflateDecompress(data []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// genSeededPublicKeyShare draws a fresh random seed and derives sk's public
+// key as a single-party degenerate case of the CKG (collective key
+// generation) protocol already used for threshold key generation in
+// multiparty.go: the CRP sampled from the seed stands in for the public
+// key's uniform "a" component, and the CKG round-1 share is exactly the
+// key's "b" component (-s*a + e). Transmitting only the seed and the share,
+// instead of the full (b, a) public key, is what fheGenPublicKeyShare's
+// "compressed" option saves on the wire.
+func genSeededPublicKeyShare(sk *rlwe.SecretKey) (seed, shareBytes []byte, err error) {
+	seed = make([]byte, compressedKeySeedBytes)
+	if _, err = rand.Read(seed); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate public key seed: %v", err)
+	}
+
+	ckgProto := multiparty.NewPublicKeyGenProtocol(params)
+	prng, err := sampling.NewKeyedPRNG(seed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build keyed PRNG from seed: %v", err)
+	}
+	crp := ckgProto.SampleCRP(prng)
+
+	share := ckgProto.AllocateShare()
+	if err := ckgProto.GenShare(sk, crp, &share); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate public key share: %v", err)
+	}
+
+	shareBytes, err = share.MarshalBinary()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal public key share: %v", err)
+	}
+	return seed, shareBytes, nil
+}
+
+// expandSeededPublicKey is genSeededPublicKeyShare's inverse: it re-derives
+// the same CRP from seed and folds it back with the transmitted share to
+// recover the full public key.
+func expandSeededPublicKey(seed, shareBytes []byte) (*rlwe.PublicKey, error) {
+	ckgProto := multiparty.NewPublicKeyGenProtocol(params)
+	prng, err := sampling.NewKeyedPRNG(seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build keyed PRNG from seed: %v", err)
+	}
+	crp := ckgProto.SampleCRP(prng)
+
+	share := ckgProto.AllocateShare()
+	if err := share.UnmarshalBinary(shareBytes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal public key share: %v", err)
+	}
+
+	pk := rlwe.NewPublicKey(params)
+	ckgProto.GenPublicKey(share, crp, pk)
+	return pk, nil
+}
+
+// optionsCompressed reports whether args[index] is present and its
+// "compressed" field is truthy; used by keygenWrapper, genRelinearizationKeyWrapper,
+// genGaloisKeysWrapper, and genAllKeysWrapper to accept an optional trailing
+// {compressed: true} argument without breaking existing call sites that
+// don't pass one.
+func optionsCompressed(args []js.Value, index int) bool {
+	if index >= len(args) || args[index].IsUndefined() || args[index].IsNull() {
+		return false
+	}
+	compressed := args[index].Get("compressed")
+	return !compressed.IsUndefined() && compressed.Truthy()
+}
+
+// encodeMarshalerCompressed marshals b and, if compressed, wraps it in a
+// keyEnvelopeDeflate envelope; the caller always gets back a Uint8Array
+// whose bytes are ready to send over the wasm boundary.
+func encodeMarshalerCompressed(b interface{ MarshalBinary() ([]byte, error) }, compressed bool) (js.Value, error) {
+	raw, err := b.MarshalBinary()
+	if err != nil {
+		return js.Value{}, err
+	}
+	if !compressed {
+		return goBytesToJS(raw), nil
+	}
+	deflated, err := deflateCompress(raw)
+	if err != nil {
+		return js.Value{}, fmt.Errorf("failed to compress key: %v", err)
+	}
+	return goBytesToJS(encodeKeyEnvelope(keyEnvelopeDeflate, nil, deflated)), nil
+}
+
+// fheExpandKeysWrapper: 압축된 키 번들을 원본 형태로 복원 (compressedKeys) -> keys
+// compressedKeys mirrors genAllKeysWrapper's result shape, except any field
+// generated with {compressed: true} carries a keyEnvelope instead of a raw
+// marshaled key; fields that were never compressed pass straight through
+// unmodified, so a caller can mix compressed and uncompressed fields freely.
+func fheExpandKeysWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return js.Global().Get("Error").New("fheExpandKeys requires 1 argument: compressedKeys (object)")
+	}
+
+	compressedKeys := args[0]
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("ExpandKeys failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			result := js.Global().Get("Object").New()
+
+			expandField := func(fieldName string) error {
+				field := compressedKeys.Get(fieldName)
+				if field.IsUndefined() || field.IsNull() {
+					return nil
+				}
+				data := jsBytesToGo(field)
+
+				kind, seed, payload, err := decodeKeyEnvelope(data)
+				if err != nil {
+					// Not every envelope is compressed; pass an unrecognized
+					// (i.e. plain, uncompressed) blob straight through.
+					result.Set(fieldName, field)
+					return nil
+				}
+
+				switch kind {
+				case keyEnvelopeSeededPublicKey:
+					pk, err := expandSeededPublicKey(seed, payload)
+					if err != nil {
+						return fmt.Errorf("failed to expand %s: %v", fieldName, err)
+					}
+					pkBytes, err := pk.MarshalBinary()
+					if err != nil {
+						return fmt.Errorf("failed to marshal expanded %s: %v", fieldName, err)
+					}
+					result.Set(fieldName, goBytesToJS(pkBytes))
+				case keyEnvelopeDeflate:
+					raw, err := deflateDecompress(payload)
+					if err != nil {
+						return fmt.Errorf("failed to decompress %s: %v", fieldName, err)
+					}
+					result.Set(fieldName, goBytesToJS(raw))
+				default:
+					return fmt.Errorf("%s: unsupported key envelope kind %d", fieldName, kind)
+				}
+				return nil
+			}
+
+			for _, fieldName := range []string{"secretKey", "publicKey", "relinearizationKey"} {
+				if err := expandField(fieldName); err != nil {
+					reject.Invoke(js.Global().Get("Error").New(err.Error()))
+					return
+				}
+			}
+
+			galoisKeysField := compressedKeys.Get("galoisKeys")
+			if !galoisKeysField.IsUndefined() && !galoisKeysField.IsNull() {
+				expandedGks := js.Global().Get("Array").New()
+				for i := 0; i < galoisKeysField.Length(); i++ {
+					data := jsBytesToGo(galoisKeysField.Index(i))
+					kind, _, payload, err := decodeKeyEnvelope(data)
+					if err != nil {
+						expandedGks.Call("push", galoisKeysField.Index(i))
+						continue
+					}
+					if kind != keyEnvelopeDeflate {
+						reject.Invoke(js.Global().Get("Error").New(fmt.Sprintf("galoisKeys[%d]: unsupported key envelope kind %d", i, kind)))
+						return
+					}
+					raw, err := deflateDecompress(payload)
+					if err != nil {
+						reject.Invoke(js.Global().Get("Error").New(fmt.Sprintf("failed to decompress galoisKeys[%d]: %v", i, err)))
+						return
+					}
+					expandedGks.Call("push", goBytesToJS(raw))
+				}
+				result.Set("galoisKeys", expandedGks)
+			}
+
+			resolve.Invoke(result)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"syscall/js"
+)
+
+// keyStoreMagic tags a fheSaveKeysToStore container so fheLoadKeysFromStore
+// can reject anything that isn't one before trying to parse it.
+const keyStoreMagic = "CKKSKS01"
+
+const (
+	keyStoreDBName    = "lattigo-ckks-keystore"
+	keyStoreDBVersion = 1
+	keyStoreName      = "keys"
+)
+
+// jsBytesToGo copies a JS Uint8Array into a Go byte slice.
+func jsBytesToGo(arr js.Value) []byte {
+	b := make([]byte, arr.Get("length").Int())
+	js.CopyBytesToGo(b, arr)
+	return b
+}
+
+func goBytesToJS(b []byte) js.Value {
+	arr := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(arr, b)
+	return arr
+}
+
+func writeLenPrefixed(buf *bytes.Buffer, data []byte) {
+	binary.Write(buf, binary.LittleEndian, uint32(len(data)))
+	buf.Write(data)
+}
+
+// encodeKeyStoreContainer packs a parameter fingerprint and the SK/PK/RLK/GK
+// bundle into magic bytes + length-prefixed blobs, so fheLoadKeysFromStore
+// can validate and unpack it without any out-of-band schema.
+func encodeKeyStoreContainer(fingerprint, sk, pk, rlk []byte, gks [][]byte) []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteString(keyStoreMagic)
+	writeLenPrefixed(buf, fingerprint)
+	writeLenPrefixed(buf, sk)
+	writeLenPrefixed(buf, pk)
+	writeLenPrefixed(buf, rlk)
+	binary.Write(buf, binary.LittleEndian, uint32(len(gks)))
+	for _, gk := range gks {
+		writeLenPrefixed(buf, gk)
+	}
+	return buf.Bytes()
+}
+
+// decodeKeyStoreContainer is encodeKeyStoreContainer's inverse; it returns an
+// error (not a panic) on a bad magic or a truncated blob, since a container
+// written by a stale wasm build is expected input, not a bug.
+func decodeKeyStoreContainer(data []byte) (fingerprint, sk, pk, rlk []byte, gks [][]byte, err error) {
+	if len(data) < len(keyStoreMagic) || string(data[:len(keyStoreMagic)]) != keyStoreMagic {
+		return nil, nil, nil, nil, nil, fmt.Errorf("not a valid key store container (bad magic)")
+	}
+	r := bytes.NewReader(data[len(keyStoreMagic):])
+
+	readBlob := func(label string) ([]byte, error) {
+		var length uint32
+		if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+			return nil, fmt.Errorf("failed to read %s length: %v", label, err)
+		}
+		blob := make([]byte, length)
+		if _, err := io.ReadFull(r, blob); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", label, err)
+		}
+		return blob, nil
+	}
+
+	if fingerprint, err = readBlob("fingerprint"); err != nil {
+		return
+	}
+	if sk, err = readBlob("secret key"); err != nil {
+		return
+	}
+	if pk, err = readBlob("public key"); err != nil {
+		return
+	}
+	if rlk, err = readBlob("relinearization key"); err != nil {
+		return
+	}
+
+	var gkCount uint32
+	if err = binary.Read(r, binary.LittleEndian, &gkCount); err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("failed to read Galois key count: %v", err)
+	}
+	gks = make([][]byte, gkCount)
+	for i := range gks {
+		if gks[i], err = readBlob(fmt.Sprintf("Galois key %d", i)); err != nil {
+			return nil, nil, nil, nil, nil, err
+		}
+	}
+	return fingerprint, sk, pk, rlk, gks, nil
+}
+
+// openKeyStoreDB opens (and, on first use, upgrades) the shared IndexedDB
+// database every fheSaveKeysToStore/fheLoadKeysFromStore call stores its
+// container under. onSuccess/onError run on whichever goroutine the
+// indexedDB event loop schedules the callback on.
+func openKeyStoreDB(onSuccess func(db js.Value), onError func(msg string)) {
+	indexedDB := js.Global().Get("indexedDB")
+	if indexedDB.IsUndefined() {
+		onError("indexedDB is not available in this environment")
+		return
+	}
+
+	openReq := indexedDB.Call("open", keyStoreDBName, keyStoreDBVersion)
+
+	openReq.Set("onupgradeneeded", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		db := args[0].Get("target").Get("result")
+		if !db.Call("objectStoreNames").Call("contains", keyStoreName).Bool() {
+			db.Call("createObjectStore", keyStoreName)
+		}
+		return nil
+	}))
+
+	openReq.Set("onsuccess", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		onSuccess(args[0].Get("target").Get("result"))
+		return nil
+	}))
+
+	openReq.Set("onerror", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		onError(fmt.Sprintf("failed to open key store database: %v", openReq.Get("error")))
+		return nil
+	}))
+}
+
+// putKeyStoreRecord stores data under name in the keys object store,
+// replacing any existing record with that name.
+func putKeyStoreRecord(name string, data js.Value, onSuccess func(), onError func(string)) {
+	openKeyStoreDB(func(db js.Value) {
+		store := db.Call("transaction", keyStoreName, "readwrite").Call("objectStore", keyStoreName)
+		putReq := store.Call("put", data, name)
+
+		putReq.Set("onsuccess", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			onSuccess()
+			return nil
+		}))
+		putReq.Set("onerror", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			onError(fmt.Sprintf("failed to write key store record %q: %v", name, putReq.Get("error")))
+			return nil
+		}))
+	}, onError)
+}
+
+// getKeyStoreRecord looks up name in the keys object store. onNotFound runs
+// (not onError) when the store simply has no record under that name yet.
+func getKeyStoreRecord(name string, onSuccess func(data js.Value), onNotFound func(), onError func(string)) {
+	openKeyStoreDB(func(db js.Value) {
+		store := db.Call("transaction", keyStoreName, "readonly").Call("objectStore", keyStoreName)
+		getReq := store.Call("get", name)
+
+		getReq.Set("onsuccess", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			result := getReq.Get("result")
+			if result.IsUndefined() || result.IsNull() {
+				onNotFound()
+				return nil
+			}
+			onSuccess(result)
+			return nil
+		}))
+		getReq.Set("onerror", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			onError(fmt.Sprintf("failed to read key store record %q: %v", name, getReq.Get("error")))
+			return nil
+		}))
+	}, onError)
+}
+
+// fheSaveKeysToStoreWrapper: 키 번들을 IndexedDB에 저장 (name, keys) -> true
+// keys is a JS object shaped like genAllKeysWrapper's result:
+// {secretKey, publicKey, relinearizationKey, galoisKeys: [Uint8Array, ...]}.
+// The bundle is stamped with the current params fingerprint before storage
+// so a later fheLoadKeysFromStore under different parameters is caught
+// instead of silently returning keys for the wrong ring.
+func fheSaveKeysToStoreWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return js.Global().Get("Error").New("fheSaveKeysToStore requires 2 arguments: name (string), keys (object with secretKey/publicKey/relinearizationKey/galoisKeys)")
+	}
+
+	name := args[0].String()
+	keysObj := args[1]
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("SaveKeysToStore failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			skBytes := jsBytesToGo(keysObj.Get("secretKey"))
+			pkBytes := jsBytesToGo(keysObj.Get("publicKey"))
+			rlkBytes := jsBytesToGo(keysObj.Get("relinearizationKey"))
+
+			gksJS := keysObj.Get("galoisKeys")
+			gks := make([][]byte, gksJS.Length())
+			for i := range gks {
+				gks[i] = jsBytesToGo(gksJS.Index(i))
+			}
+
+			fingerprint, err := params.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to fingerprint parameters: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			container := encodeKeyStoreContainer(fingerprint, skBytes, pkBytes, rlkBytes, gks)
+
+			putKeyStoreRecord(name, goBytesToJS(container), func() {
+				resolve.Invoke(js.ValueOf(true))
+			}, func(msg string) {
+				reject.Invoke(js.Global().Get("Error").New(msg))
+			})
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
+
+// fheLoadKeysFromStoreWrapper: 저장된 키 번들 로드 (name) -> keys
+// Rejects with a ParameterMismatchError-named Error (not the generic
+// "Error" every other rejection here uses) when the stored fingerprint
+// doesn't match params.MarshalBinary(), so callers can distinguish "no such
+// entry" and "wrong ring parameters, must regenerate" from a plain I/O
+// failure.
+func fheLoadKeysFromStoreWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return js.Global().Get("Error").New("fheLoadKeysFromStore requires 1 argument: name (string)")
+	}
+
+	name := args[0].String()
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("LoadKeysFromStore failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			getKeyStoreRecord(name, func(data js.Value) {
+				fingerprint, sk, pk, rlk, gks, err := decodeKeyStoreContainer(jsBytesToGo(data))
+				if err != nil {
+					reject.Invoke(js.Global().Get("Error").New(fmt.Sprintf("Failed to parse stored key container: %v", err)))
+					return
+				}
+
+				expectedFingerprint, err := params.MarshalBinary()
+				if err != nil {
+					reject.Invoke(js.Global().Get("Error").New(fmt.Sprintf("Failed to fingerprint parameters: %v", err)))
+					return
+				}
+				if !bytes.Equal(fingerprint, expectedFingerprint) {
+					mismatch := js.Global().Get("Error").New(fmt.Sprintf("stored key bundle %q was generated under different CKKS parameters and must be regenerated", name))
+					mismatch.Set("name", "ParameterMismatchError")
+					reject.Invoke(mismatch)
+					return
+				}
+
+				gksJS := js.Global().Get("Array").New()
+				for _, gk := range gks {
+					gksJS.Call("push", goBytesToJS(gk))
+				}
+
+				result := js.Global().Get("Object").New()
+				result.Set("secretKey", goBytesToJS(sk))
+				result.Set("publicKey", goBytesToJS(pk))
+				result.Set("relinearizationKey", goBytesToJS(rlk))
+				result.Set("galoisKeys", gksJS)
+				resolve.Invoke(result)
+			}, func() {
+				notFound := js.Global().Get("Error").New(fmt.Sprintf("no key bundle stored under name %q", name))
+				notFound.Set("name", "KeyBundleNotFoundError")
+				reject.Invoke(notFound)
+			}, func(msg string) {
+				reject.Invoke(js.Global().Get("Error").New(msg))
+			})
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
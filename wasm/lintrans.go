@@ -0,0 +1,305 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/lintrans"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// unmarshalGaloisKeys decodes the JS array of Uint8Array genGaloisKeys
+// returns (one marshaled *rlwe.GaloisKey per element) back into Go values.
+func unmarshalGaloisKeys(gksArray js.Value) ([]*rlwe.GaloisKey, error) {
+	length := gksArray.Length()
+	gks := make([]*rlwe.GaloisKey, length)
+	for i := 0; i < length; i++ {
+		gkArray := gksArray.Index(i)
+		gkBytes := make([]byte, gkArray.Get("length").Int())
+		js.CopyBytesToGo(gkBytes, gkArray)
+
+		gk := &rlwe.GaloisKey{}
+		if err := gk.UnmarshalBinary(gkBytes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Galois key %d: %v", i, err)
+		}
+		gks[i] = gk
+	}
+	return gks, nil
+}
+
+// buildEvaluatorWithGaloisKeys pairs buildEvaluator's rlk deserialization with
+// gks, giving an evaluator that can both relinearize and rotate.
+func buildEvaluatorWithGaloisKeys(rlkBytes []byte, gks []*rlwe.GaloisKey) (*ckks.Evaluator, error) {
+	rlk := &rlwe.RelinearizationKey{}
+	if err := rlk.UnmarshalBinary(rlkBytes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal relinearization key: %v", err)
+	}
+	evk := rlwe.NewMemEvaluationKeySet(rlk, gks...)
+	return ckks.NewEvaluator(params, evk), nil
+}
+
+// broadcastSumDiagonals builds the diagonal representation of the numFeatures
+// x numFeatures circulant matrix whose every row equals weights, so that
+// lintrans.Evaluator.EvaluateNew(ct, transform) leaves Σ w_i*x_i in every
+// slot of the corresponding numFeatures-wide block, in one BSGS pass over
+// O(sqrt(numFeatures)) rotations instead of the numFeatures-1 rotations a
+// naive per-diagonal application would need. diagonal d holds weights
+// rotated left by d, since a circulant row-broadcast matrix W has
+// W[j, (j+d) mod n] = weights[(j+d) mod n] for every row j.
+func broadcastSumDiagonals(weights []float64) lintrans.Diagonals[float64] {
+	n := len(weights)
+	diagonals := make(lintrans.Diagonals[float64], n)
+	for d := 0; d < n; d++ {
+		row := make([]float64, n)
+		for j := 0; j < n; j++ {
+			row[j] = weights[(j+d)%n]
+		}
+		diagonals[d] = row
+	}
+	return diagonals
+}
+
+// fheLinearTransformWrapper: 대각 표현 선형 변환으로 가중합 계산 (ciphertext, weightsJSON, gks, rlk) -> ciphertext
+// weightsJSON is a JSON array of the plaintext weight vector (the same
+// values a JS Float64Array would carry over the wasm boundary); it is
+// encoded here as the diagonals of a row-broadcast matrix and evaluated with
+// Lattigo's BSGS lintrans.Evaluator, replacing InferCreditScore's
+// rotate-and-add tree with a single hoisted linear transform.
+func fheLinearTransformWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 4 {
+		return js.Global().Get("Error").New("fheLinearTransform requires 4 arguments: ciphertext (Uint8Array), weights (JSON array), galoisKeys (Array of Uint8Array), relinearizationKey (Uint8Array)")
+	}
+
+	ctArray := args[0]
+	weightsJSON := args[1].String()
+	gksArray := args[2]
+	rlkArray := args[3]
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("LinearTransform failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			var weights []float64
+			if err := json.Unmarshal([]byte(weightsJSON), &weights); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to parse weights: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			ctBytes := make([]byte, ctArray.Get("length").Int())
+			js.CopyBytesToGo(ctBytes, ctArray)
+			ct := &rlwe.Ciphertext{}
+			if err := ct.UnmarshalBinary(ctBytes); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			gks, err := unmarshalGaloisKeys(gksArray)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(err.Error())
+				reject.Invoke(errorObject)
+				return
+			}
+
+			rlkBytes := make([]byte, rlkArray.Get("length").Int())
+			js.CopyBytesToGo(rlkBytes, rlkArray)
+			evaluator, err := buildEvaluatorWithGaloisKeys(rlkBytes, gks)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(err.Error())
+				reject.Invoke(errorObject)
+				return
+			}
+
+			diagonals := broadcastSumDiagonals(weights)
+			ltParams := lintrans.Parameters{
+				DiagonalsIndexList: diagonals.DiagonalsIndexList(),
+				LevelQ:             ct.Level(),
+				Scale:              params.DefaultScale(),
+			}
+
+			transform := lintrans.NewTransformation(params, ltParams)
+			if err := lintrans.Encode(encoder, diagonals, transform); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to encode linear transform: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			ltEvaluator := lintrans.NewEvaluator(evaluator)
+			result, err := ltEvaluator.EvaluateNew(ct, transform)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Linear transform evaluation failed: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			resultBytes, err := result.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal result ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			resultArray := js.Global().Get("Uint8Array").New(len(resultBytes))
+			js.CopyBytesToJS(resultArray, resultBytes)
+			resolve.Invoke(resultArray)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
+
+// blockMask returns a plaintext that is 1 in every slot whose position
+// within its numFeatures-wide block is less than numFeatures-step, and 0
+// elsewhere, zeroing out the block-boundary contribution a rotate-by-step
+// would otherwise wrap in from a neighboring customer's block.
+func blockMask(step, numFeatures, numBlocks, level int) (*rlwe.Plaintext, error) {
+	values := make([]complex128, params.MaxSlots())
+	for b := 0; b < numBlocks; b++ {
+		for j := 0; j < numFeatures-step; j++ {
+			values[b*numFeatures+j] = complex(1, 0)
+		}
+	}
+	pt := ckks.NewPlaintext(params, level)
+	if err := encoder.Encode(values, pt); err != nil {
+		return nil, fmt.Errorf("failed to encode block mask: %v", err)
+	}
+	return pt, nil
+}
+
+// innerSumBlocks reduces batchSize independent numFeatures-wide blocks
+// packed into one ciphertext down to their per-block sums (one sum per
+// block, left in every slot of that block), via a power-of-two rotate-mask-
+// add tree masked at each step so a block's sum never picks up its
+// neighbor's values across the block boundary.
+func innerSumBlocks(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, batchSize, numFeatures int) (*rlwe.Ciphertext, error) {
+	sum := ct.CopyNew()
+	for step := 1; step < nextPowerOfTwoLinTrans(numFeatures); step *= 2 {
+		rotated, err := evaluator.RotateNew(sum, step)
+		if err != nil {
+			return nil, fmt.Errorf("innerSumBlocks Rotate(%d) failed: %v", step, err)
+		}
+
+		mask, err := blockMask(step, numFeatures, batchSize, rotated.Level())
+		if err != nil {
+			return nil, err
+		}
+		masked, err := evaluator.MulNew(rotated, mask)
+		if err != nil {
+			return nil, fmt.Errorf("innerSumBlocks mask mul(%d) failed: %v", step, err)
+		}
+		if err := evaluator.Rescale(masked, masked); err != nil {
+			return nil, fmt.Errorf("innerSumBlocks mask rescale(%d) failed: %v", step, err)
+		}
+
+		if err := evaluator.Add(sum, masked, sum); err != nil {
+			return nil, fmt.Errorf("innerSumBlocks Add(rotate %d) failed: %v", step, err)
+		}
+	}
+	return sum, nil
+}
+
+func nextPowerOfTwoLinTrans(n int) int {
+	p := 1
+	for p < n {
+		p *= 2
+	}
+	return p
+}
+
+// fheInnerSumWrapper: 블록 단위 내적 합산 (ciphertext, batchSize, numFeatures, gks) -> ciphertext
+// ct packs batchSize customers, numFeatures already-weighted slots each
+// (block b occupies slots [b*numFeatures, (b+1)*numFeatures)); fheInnerSum
+// reduces every block to its Σ w_i*x_i in one hoisted rotate-mask-add tree,
+// the batched counterpart to InnerProduct in internal/app/inference.go.
+func fheInnerSumWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 4 {
+		return js.Global().Get("Error").New("fheInnerSum requires 4 arguments: ciphertext (Uint8Array), batchSize (number), numFeatures (number), galoisKeys (Array of Uint8Array)")
+	}
+
+	ctArray := args[0]
+	batchSize := args[1].Int()
+	numFeatures := args[2].Int()
+	gksArray := args[3]
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("InnerSum failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			ctBytes := make([]byte, ctArray.Get("length").Int())
+			js.CopyBytesToGo(ctBytes, ctArray)
+			ct := &rlwe.Ciphertext{}
+			if err := ct.UnmarshalBinary(ctBytes); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			gks, err := unmarshalGaloisKeys(gksArray)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(err.Error())
+				reject.Invoke(errorObject)
+				return
+			}
+			evk := rlwe.NewMemEvaluationKeySet(nil, gks...)
+			evaluator := ckks.NewEvaluator(params, evk)
+
+			result, err := innerSumBlocks(evaluator, ct, batchSize, numFeatures)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(err.Error())
+				reject.Invoke(errorObject)
+				return
+			}
+
+			resultBytes, err := result.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal result ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			resultArray := js.Global().Get("Uint8Array").New(len(resultBytes))
+			js.CopyBytesToJS(resultArray, resultBytes)
+			resolve.Invoke(resultArray)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
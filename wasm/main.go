@@ -7,11 +7,19 @@ import (
 
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
 	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks/bootstrapping"
 )
 
 var (
 	params  ckks.Parameters
 	encoder *ckks.Encoder // ⚡ 전역 인코더 캐시 (재사용)
+
+	// btpParamsCache/btpEvalCache hold the bootstrapping parameter set and
+	// evaluator built by the most recent fheGenBootstrappingKeys call, so
+	// fheBootstrap doesn't need to rebuild bootstrapping.Parameters (a
+	// non-trivial circuit-planning step) on every ciphertext it refreshes.
+	btpParamsCache bootstrapping.Parameters
+	btpEvalCache   *bootstrapping.Evaluator
 )
 
 func init() {
@@ -36,6 +44,10 @@ func init() {
 
 // keygenWrapper: FHE 키 쌍 생성
 func keygenWrapper(this js.Value, args []js.Value) interface{} {
+	// options.compressed: true returns publicKey as a seeded key envelope
+	// (see genSeededPublicKeyShare) instead of the full (b, a) key.
+	compressed := optionsCompressed(args, 0)
+
 	// Promise 생성
 	handler := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		resolve := args[0]
@@ -53,7 +65,6 @@ func keygenWrapper(this js.Value, args []js.Value) interface{} {
 			// 키 생성
 			kgen := ckks.NewKeyGenerator(params)
 			sk := kgen.GenSecretKeyNew()
-			pk := kgen.GenPublicKeyNew(sk)
 
 			// 직렬화
 			skBytes, err := sk.MarshalBinary()
@@ -64,20 +75,31 @@ func keygenWrapper(this js.Value, args []js.Value) interface{} {
 				return
 			}
 
-			pkBytes, err := pk.MarshalBinary()
-			if err != nil {
-				errorConstructor := js.Global().Get("Error")
-				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal public key: %v", err))
-				reject.Invoke(errorObject)
-				return
-			}
-
 			// JavaScript Uint8Array로 변환
 			skArray := js.Global().Get("Uint8Array").New(len(skBytes))
 			js.CopyBytesToJS(skArray, skBytes)
 
-			pkArray := js.Global().Get("Uint8Array").New(len(pkBytes))
-			js.CopyBytesToJS(pkArray, pkBytes)
+			var pkArray js.Value
+			if compressed {
+				seed, shareBytes, err := genSeededPublicKeyShare(sk)
+				if err != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(err.Error())
+					reject.Invoke(errorObject)
+					return
+				}
+				pkArray = goBytesToJS(encodeKeyEnvelope(keyEnvelopeSeededPublicKey, seed, shareBytes))
+			} else {
+				pk := kgen.GenPublicKeyNew(sk)
+				pkBytes, err := pk.MarshalBinary()
+				if err != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal public key: %v", err))
+					reject.Invoke(errorObject)
+					return
+				}
+				pkArray = goBytesToJS(pkBytes)
+			}
 
 			// 결과 객체 생성
 			result := js.Global().Get("Object").New()
@@ -96,11 +118,12 @@ func keygenWrapper(this js.Value, args []js.Value) interface{} {
 
 // genRelinearizationKeyWrapper: Relinearization Key 생성
 func genRelinearizationKeyWrapper(this js.Value, args []js.Value) interface{} {
-	if len(args) != 1 {
-		return js.Global().Get("Error").New("genRelinearizationKey requires 1 argument: secretKey (Uint8Array)")
+	if len(args) < 1 {
+		return js.Global().Get("Error").New("genRelinearizationKey requires at least 1 argument: secretKey (Uint8Array), optional: options ({compressed})")
 	}
 
 	skArray := args[0]
+	compressed := optionsCompressed(args, 1)
 
 	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
 		resolve := promiseArgs[0]
@@ -131,8 +154,8 @@ func genRelinearizationKeyWrapper(this js.Value, args []js.Value) interface{} {
 			kgen := ckks.NewKeyGenerator(params)
 			rlk := kgen.GenRelinearizationKeyNew(sk)
 
-			// 직렬화
-			rlkBytes, err := rlk.MarshalBinary()
+			// 직렬화 (compressed 옵션이 true면 DEFLATE로 압축)
+			rlkArray, err := encodeMarshalerCompressed(rlk, compressed)
 			if err != nil {
 				errorConstructor := js.Global().Get("Error")
 				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal relinearization key: %v", err))
@@ -140,10 +163,6 @@ func genRelinearizationKeyWrapper(this js.Value, args []js.Value) interface{} {
 				return
 			}
 
-			// JavaScript Uint8Array로 변환
-			rlkArray := js.Global().Get("Uint8Array").New(len(rlkBytes))
-			js.CopyBytesToJS(rlkArray, rlkBytes)
-
 			resolve.Invoke(rlkArray)
 		}()
 
@@ -173,6 +192,8 @@ func genGaloisKeysWrapper(this js.Value, args []js.Value) interface{} {
 		}
 	}
 
+	compressed := optionsCompressed(args, 2)
+
 	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
 		resolve := promiseArgs[0]
 		reject := promiseArgs[1]
@@ -216,18 +237,16 @@ func genGaloisKeysWrapper(this js.Value, args []js.Value) interface{} {
 				gks = kgen.GenGaloisKeysNew(galoisElements, sk)
 			}
 
-			// 개별 직렬화 후 JSON 배열로 반환
+			// 개별 직렬화 후 JSON 배열로 반환 (compressed 옵션이 true면 각 키를 DEFLATE로 압축)
 			result := js.Global().Get("Array").New()
 			for _, gk := range gks {
-				gkBytes, err := gk.MarshalBinary()
+				gkArray, err := encodeMarshalerCompressed(gk, compressed)
 				if err != nil {
 					errorConstructor := js.Global().Get("Error")
 					errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal Galois key: %v", err))
 					reject.Invoke(errorObject)
 					return
 				}
-				gkArray := js.Global().Get("Uint8Array").New(len(gkBytes))
-				js.CopyBytesToJS(gkArray, gkBytes)
 				result.Call("push", gkArray)
 			}
 
@@ -379,6 +398,8 @@ func genConjugationKeyWrapper(this js.Value, args []js.Value) interface{} {
 
 // genAllKeysWrapper: 모든 평가 키 한번에 생성 (SK, PK, RLK, Galois Keys)
 func genAllKeysWrapper(this js.Value, args []js.Value) interface{} {
+	compressed := optionsCompressed(args, 0)
+
 	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
 		resolve := promiseArgs[0]
 		reject := promiseArgs[1]
@@ -397,7 +418,6 @@ func genAllKeysWrapper(this js.Value, args []js.Value) interface{} {
 
 			// 1. Secret Key & Public Key
 			sk := kgen.GenSecretKeyNew()
-			pk := kgen.GenPublicKeyNew(sk)
 
 			// 2. Relinearization Key
 			rlk := kgen.GenRelinearizationKeyNew(sk)
@@ -416,27 +436,43 @@ func genAllKeysWrapper(this js.Value, args []js.Value) interface{} {
 
 			gks := kgen.GenGaloisKeysNew(galEls, sk)
 
-			// 직렬화
+			// 직렬화 (compressed 옵션이 true면 PK는 시드+share로, RLK/GK는 DEFLATE로 압축)
 			skBytes, _ := sk.MarshalBinary()
-			pkBytes, _ := pk.MarshalBinary()
-			rlkBytes, _ := rlk.MarshalBinary()
-
-			// JavaScript Uint8Array로 변환
 			skArray := js.Global().Get("Uint8Array").New(len(skBytes))
 			js.CopyBytesToJS(skArray, skBytes)
 
-			pkArray := js.Global().Get("Uint8Array").New(len(pkBytes))
-			js.CopyBytesToJS(pkArray, pkBytes)
+			var pkArray js.Value
+			if compressed {
+				seed, shareBytes, err := genSeededPublicKeyShare(sk)
+				if err != nil {
+					reject.Invoke(js.Global().Get("Error").New(err.Error()))
+					return
+				}
+				pkArray = goBytesToJS(encodeKeyEnvelope(keyEnvelopeSeededPublicKey, seed, shareBytes))
+			} else {
+				pk := kgen.GenPublicKeyNew(sk)
+				pkBytes, err := pk.MarshalBinary()
+				if err != nil {
+					reject.Invoke(js.Global().Get("Error").New(fmt.Sprintf("Failed to marshal public key: %v", err)))
+					return
+				}
+				pkArray = goBytesToJS(pkBytes)
+			}
 
-			rlkArray := js.Global().Get("Uint8Array").New(len(rlkBytes))
-			js.CopyBytesToJS(rlkArray, rlkBytes)
+			rlkArray, err := encodeMarshalerCompressed(rlk, compressed)
+			if err != nil {
+				reject.Invoke(js.Global().Get("Error").New(fmt.Sprintf("Failed to marshal relinearization key: %v", err)))
+				return
+			}
 
 			// Galois Keys 배열
 			gksArrayJS := js.Global().Get("Array").New()
 			for _, gk := range gks {
-				gkBytes, _ := gk.MarshalBinary()
-				gkArray := js.Global().Get("Uint8Array").New(len(gkBytes))
-				js.CopyBytesToJS(gkArray, gkBytes)
+				gkArray, err := encodeMarshalerCompressed(gk, compressed)
+				if err != nil {
+					reject.Invoke(js.Global().Get("Error").New(fmt.Sprintf("Failed to marshal Galois key: %v", err)))
+					return
+				}
 				gksArrayJS.Call("push", gkArray)
 			}
 
@@ -457,6 +493,233 @@ func genAllKeysWrapper(this js.Value, args []js.Value) interface{} {
 	return promiseConstructor.New(handler)
 }
 
+// BootstrappingParametersLiteral is the JSON knob JS callers pass to
+// fheGenBootstrappingKeys, letting them pick a bootstrapping precision/depth
+// tradeoff without recompiling the WASM binary.
+type BootstrappingParametersLiteral struct {
+	// Variant selects "residual" (default: fewer residual primes reserved
+	// for post-bootstrap precision, faster) or "high-precision" (reserves
+	// more residual primes, trading bootstrap speed for output precision).
+	Variant string `json:"variant"`
+}
+
+// bootstrappableParams builds the LogN=16 residual CKKS parameter set each
+// BootstrappingParametersLiteral.Variant maps to — the same larger ring
+// dimension cmd/benchmark/main.go's -bootstrap flag uses, since
+// bootstrapping needs the extra headroom a LogN=13 chain doesn't have.
+func bootstrappableParams(variant string) (ckks.Parameters, error) {
+	logQ := append([]int{60}, repeatIntWasm(40, 20)...)
+	logP := []int{61, 61, 61, 61}
+	if variant == "high-precision" {
+		logQ = append([]int{60}, repeatIntWasm(45, 24)...)
+		logP = []int{61, 61, 61, 61, 61}
+	}
+	return ckks.NewParametersFromLiteral(ckks.ParametersLiteral{
+		LogN:            16,
+		LogQ:            logQ,
+		LogP:            logP,
+		LogDefaultScale: 40,
+	})
+}
+
+func repeatIntWasm(v, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
+
+// fheGenBootstrappingKeysWrapper: 부트스트래핑 평가 키 생성 (secretKey, btpParamsLiteral JSON) -> bootstrappingKeys
+// Builds a bootstrapping.Parameters for the requested variant, generates its
+// evaluation key set from secretKey, and caches the resulting evaluator for
+// fheBootstrap — while also handing the caller the serialized key set, the
+// same way genAllKeysWrapper serializes SK/PK/RLK/Galois keys to Uint8Array.
+func fheGenBootstrappingKeysWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return js.Global().Get("Error").New("fheGenBootstrappingKeys requires 2 arguments: secretKey (Uint8Array), btpParamsLiteral (JSON string)")
+	}
+
+	skArray := args[0]
+	btpLiteralJSON := args[1].String()
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("GenBootstrappingKeys failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			var lit BootstrappingParametersLiteral
+			if err := json.Unmarshal([]byte(btpLiteralJSON), &lit); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to parse btpParamsLiteral: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			residualParams, err := bootstrappableParams(lit.Variant)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to build bootstrapping-capable parameters: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			btpParams, err := bootstrapping.NewParametersFromLiteral(residualParams, bootstrapping.ParametersLiteral{})
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to build bootstrapping parameters: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			skBytes := make([]byte, skArray.Get("length").Int())
+			js.CopyBytesToGo(skBytes, skArray)
+			sk := &rlwe.SecretKey{}
+			if err := sk.UnmarshalBinary(skBytes); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal secret key: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			btpEvk, _, err := btpParams.GenEvaluationKeys(sk)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to generate bootstrapping evaluation keys: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			btpEval, err := bootstrapping.NewEvaluator(btpParams, btpEvk)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to build bootstrapping evaluator: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+			btpParamsCache = btpParams
+			btpEvalCache = btpEval
+
+			evkBytes, err := btpEvk.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal bootstrapping keys: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			evkArray := js.Global().Get("Uint8Array").New(len(evkBytes))
+			js.CopyBytesToJS(evkArray, evkBytes)
+
+			result := js.Global().Get("Object").New()
+			result.Set("bootstrappingKeys", evkArray)
+			resolve.Invoke(result)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
+
+// fheBootstrapWrapper: 소진된 암호문을 MaxLevel로 복원 (ciphertext, bootstrappingKeys)
+// Deserializes bootstrappingKeys against the bootstrapping.Parameters cached
+// by the last fheGenBootstrappingKeys call (so the caller doesn't need to
+// resend the variant on every refresh) and runs the bootstrap circuit.
+func fheBootstrapWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return js.Global().Get("Error").New("fheBootstrap requires 2 arguments: ciphertext (Uint8Array), bootstrappingKeys (Uint8Array)")
+	}
+
+	ctArray := args[0]
+	evkArray := args[1]
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("Bootstrap failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			if btpEvalCache == nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New("Bootstrapping evaluator not initialized: call fheGenBootstrappingKeys first")
+				reject.Invoke(errorObject)
+				return
+			}
+
+			evkBytes := make([]byte, evkArray.Get("length").Int())
+			js.CopyBytesToGo(evkBytes, evkArray)
+			evk := &bootstrapping.EvaluationKeySet{}
+			if err := evk.UnmarshalBinary(evkBytes); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal bootstrapping keys: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			btpEval, err := bootstrapping.NewEvaluator(btpParamsCache, evk)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to rebuild bootstrapping evaluator: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			ctBytes := make([]byte, ctArray.Get("length").Int())
+			js.CopyBytesToGo(ctBytes, ctArray)
+			ct := &rlwe.Ciphertext{}
+			if err := ct.UnmarshalBinary(ctBytes); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			refreshed, err := btpEval.Bootstrap(ct)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Bootstrap operation failed: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			refreshedBytes, err := refreshed.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal refreshed ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			refreshedArray := js.Global().Get("Uint8Array").New(len(refreshedBytes))
+			js.CopyBytesToJS(refreshedArray, refreshedBytes)
+
+			resolve.Invoke(refreshedArray)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
+
 // encryptWrapper: 평문 암호화
 func encryptWrapper(this js.Value, args []js.Value) interface{} {
 	if len(args) != 2 {
@@ -767,17 +1030,57 @@ func main() {
 	js.Global().Set("fheGenConjugationKey", js.FuncOf(genConjugationKeyWrapper))
 	js.Global().Set("fheGenAllKeys", js.FuncOf(genAllKeysWrapper))
 
+	// 부트스트래핑
+	js.Global().Set("fheGenBootstrappingKeys", js.FuncOf(fheGenBootstrappingKeysWrapper))
+	js.Global().Set("fheBootstrap", js.FuncOf(fheBootstrapWrapper))
+
+	// 멀티파티(threshold) 키 생성 및 복호화
+	js.Global().Set("fheMPCGenShare", js.FuncOf(fheMPCGenShareWrapper))
+	js.Global().Set("fheMPCAggregateShares", js.FuncOf(fheMPCAggregateSharesWrapper))
+	js.Global().Set("fheMPCPartialDecrypt", js.FuncOf(fheMPCPartialDecryptWrapper))
+	js.Global().Set("fheMPCCombinePartials", js.FuncOf(fheMPCCombinePartialsWrapper))
+
+	// 다항식/체비셰프 활성화 함수 평가 (시그모이드, ReLU, tanh 등)
+	js.Global().Set("fheEvalPolynomial", js.FuncOf(fheEvalPolynomialWrapper))
+	js.Global().Set("fheEvalChebyshev", js.FuncOf(fheEvalChebyshevWrapper))
+	js.Global().Set("fheMinimaxSigmoid", js.FuncOf(fheMinimaxSigmoidWrapper))
+
+	// 대각 표현 선형 변환 / 블록 내적 합산 (호이스트된 회전)
+	js.Global().Set("fheLinearTransform", js.FuncOf(fheLinearTransformWrapper))
+	js.Global().Set("fheInnerSum", js.FuncOf(fheInnerSumWrapper))
+
+	// IndexedDB 기반 영구 키 저장소
+	js.Global().Set("fheSaveKeysToStore", js.FuncOf(fheSaveKeysToStoreWrapper))
+	js.Global().Set("fheLoadKeysFromStore", js.FuncOf(fheLoadKeysFromStoreWrapper))
+
+	// 압축된(시드 기반/DEFLATE) 키 번들 복원
+	js.Global().Set("fheExpandKeys", js.FuncOf(fheExpandKeysWrapper))
+
 	fmt.Println("FHE functions exposed to JavaScript:")
-	fmt.Println("  - fheKeygen()")
+	fmt.Println("  - fheKeygen({compressed})")
 	fmt.Println("  - fheEncrypt(publicKey, plaintext)")
 	fmt.Println("  - fheEncryptVector(publicKey, [values])")
 	fmt.Println("  - fheDecrypt(secretKey, ciphertext)")
 	fmt.Println("  - fheGetParamsInfo()")
-	fmt.Println("  - fheGenRelinearizationKey(secretKey)")
-	fmt.Println("  - fheGenGaloisKeys(secretKey, [galoisElements])")
+	fmt.Println("  - fheGenRelinearizationKey(secretKey, {compressed})")
+	fmt.Println("  - fheGenGaloisKeys(secretKey, [galoisElements], {compressed})")
 	fmt.Println("  - fheGenRotationKeys(secretKey, [rotations])")
 	fmt.Println("  - fheGenConjugationKey(secretKey)")
-	fmt.Println("  - fheGenAllKeys()")
+	fmt.Println("  - fheGenAllKeys({compressed})")
+	fmt.Println("  - fheExpandKeys(compressedKeys)")
+	fmt.Println("  - fheGenBootstrappingKeys(secretKey, btpParamsLiteralJSON)")
+	fmt.Println("  - fheBootstrap(ciphertext, bootstrappingKeys)")
+	fmt.Println("  - fheMPCGenShare(seed, partyIndex, threshold)")
+	fmt.Println("  - fheMPCAggregateShares([roundShare, ...])")
+	fmt.Println("  - fheMPCPartialDecrypt(ciphertext, secretKeyShare)")
+	fmt.Println("  - fheMPCCombinePartials([partialDecryption, ...])")
+	fmt.Println("  - fheEvalPolynomial(ciphertext, coeffsJSON, relinearizationKey)")
+	fmt.Println("  - fheEvalChebyshev(ciphertext, coeffsJSON, intervalJSON, relinearizationKey)")
+	fmt.Println("  - fheMinimaxSigmoid(degree, intervalJSON)")
+	fmt.Println("  - fheLinearTransform(ciphertext, weightsJSON, [galoisKey, ...], relinearizationKey)")
+	fmt.Println("  - fheInnerSum(ciphertext, batchSize, numFeatures, [galoisKey, ...])")
+	fmt.Println("  - fheSaveKeysToStore(name, {secretKey, publicKey, relinearizationKey, galoisKeys})")
+	fmt.Println("  - fheLoadKeysFromStore(name)")
 
 	// 프로그램이 종료되지 않도록 무한 대기
 	select {}
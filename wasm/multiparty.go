@@ -0,0 +1,517 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/multiparty"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+// newMPCKeySwitchProtocol builds the CKS protocol fheMPCPartialDecrypt and
+// fheMPCCombinePartials both switch shares under, smudged with enough
+// Gaussian noise (mpcSmudgingNoise) that summing every party's share leaks
+// nothing beyond the plaintext itself.
+func newMPCKeySwitchProtocol() *multiparty.KeySwitchProtocol {
+	return multiparty.NewKeySwitchProtocol(params, ring.DiscreteGaussian{Sigma: mpcSmudgingNoise, Bound: 6 * mpcSmudgingNoise})
+}
+
+// mpcEnvelope bundles a round-message payload with the metadata the next
+// protocol step needs but doesn't receive as its own argument (the CRS seed
+// for CKG aggregation, this party's index for combining partial
+// decryptions), so JS only ever has to shuttle the single opaque byte string
+// each wrapper hands it back, instead of tracking that bookkeeping itself.
+type mpcEnvelope struct {
+	Seed       string `json:"seed,omitempty"`
+	PartyIndex int    `json:"partyIndex"`
+	Threshold  int    `json:"threshold,omitempty"`
+	Payload    []byte `json:"payload"`
+}
+
+func marshalEnvelope(e mpcEnvelope) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func unmarshalEnvelope(data []byte) (mpcEnvelope, error) {
+	var e mpcEnvelope
+	err := json.Unmarshal(data, &e)
+	return e, err
+}
+
+// mpcCRP derives the CKG protocol's common reference polynomial from seed,
+// so every party samples the identical CRP without an extra network round
+// just to agree on it.
+func mpcCRP(ckgProto *multiparty.PublicKeyGenProtocol, seed string) (multiparty.KeyGenCRP, error) {
+	prng, err := sampling.NewKeyedPRNG([]byte(seed))
+	if err != nil {
+		return multiparty.KeyGenCRP{}, fmt.Errorf("failed to build keyed PRNG from seed: %v", err)
+	}
+	return ckgProto.SampleCRP(prng), nil
+}
+
+// fheMPCGenShareWrapper: 이 파티의 비밀키 조각과 CKG 1라운드 공유 생성
+// (seed, partyIndex, threshold). Every party calls this with the same seed
+// and its own 0-based partyIndex; threshold records how many parties'
+// roundShare values fheMPCAggregateShares needs before it can build the
+// collective public key (lattigo's CKG/CKS protocols are additive-secret-
+// -sharing based, so "threshold" here means all `threshold` parties, not a
+// Shamir t-of-n scheme).
+func fheMPCGenShareWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 3 {
+		return js.Global().Get("Error").New("fheMPCGenShare requires 3 arguments: seed (string), partyIndex (number), threshold (number)")
+	}
+
+	seed := args[0].String()
+	partyIndex := args[1].Int()
+	threshold := args[2].Int()
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("MPCGenShare failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			if threshold < 1 {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("threshold must be >= 1, got %d", threshold))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			// This party's own secret key shard. Only the CKG round-1 share
+			// below (a masked function of it against the shared CRP) ever
+			// leaves this function as a "roundShare" meant for broadcast.
+			kgen := ckks.NewKeyGenerator(params)
+			sk := kgen.GenSecretKeyNew()
+
+			ckgProto := multiparty.NewPublicKeyGenProtocol(params)
+			crp, err := mpcCRP(ckgProto, seed)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to derive CKG CRP: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			share := ckgProto.AllocateShare()
+			if err := ckgProto.GenShare(sk, crp, &share); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to generate CKG share: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			skBytes, err := sk.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal secret key share: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+			shareBytes, err := share.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal CKG share: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			// secretKeyShare stays local: it's wrapped with this party's
+			// index so fheMPCPartialDecrypt/fheMPCCombinePartials know later
+			// which party is responsible for folding in the ciphertext's
+			// public component (see fheMPCPartialDecryptWrapper).
+			skEnvelope, err := marshalEnvelope(mpcEnvelope{PartyIndex: partyIndex, Payload: skBytes})
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to build secret key share envelope: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+			roundEnvelope, err := marshalEnvelope(mpcEnvelope{Seed: seed, PartyIndex: partyIndex, Threshold: threshold, Payload: shareBytes})
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to build round share envelope: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			skArray := js.Global().Get("Uint8Array").New(len(skEnvelope))
+			js.CopyBytesToJS(skArray, skEnvelope)
+			roundArray := js.Global().Get("Uint8Array").New(len(roundEnvelope))
+			js.CopyBytesToJS(roundArray, roundEnvelope)
+
+			result := js.Global().Get("Object").New()
+			result.Set("secretKeyShare", skArray)
+			result.Set("roundShare", roundArray)
+			resolve.Invoke(result)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
+
+// fheMPCAggregateSharesWrapper: 모든 파티의 CKG 1라운드 공유를 집계해 collective
+// public key 생성 ([roundShare, ...]).
+func fheMPCAggregateSharesWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return js.Global().Get("Error").New("fheMPCAggregateShares requires 1 argument: shares ([Uint8Array])")
+	}
+
+	sharesArg := args[0]
+	numShares := sharesArg.Get("length").Int()
+	roundArrays := make([]js.Value, numShares)
+	for i := 0; i < numShares; i++ {
+		roundArrays[i] = sharesArg.Index(i)
+	}
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("MPCAggregateShares failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			if numShares == 0 {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New("fheMPCAggregateShares requires at least one share")
+				reject.Invoke(errorObject)
+				return
+			}
+
+			ckgProto := multiparty.NewPublicKeyGenProtocol(params)
+
+			var crp multiparty.KeyGenCRP
+			var seed string
+			var threshold int
+			agg := ckgProto.AllocateShare()
+
+			for i, arr := range roundArrays {
+				envBytes := make([]byte, arr.Get("length").Int())
+				js.CopyBytesToGo(envBytes, arr)
+
+				env, err := unmarshalEnvelope(envBytes)
+				if err != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("Failed to parse round share %d: %v", i, err))
+					reject.Invoke(errorObject)
+					return
+				}
+				if i == 0 {
+					seed = env.Seed
+					threshold = env.Threshold
+					crp, err = mpcCRP(ckgProto, seed)
+					if err != nil {
+						errorConstructor := js.Global().Get("Error")
+						errorObject := errorConstructor.New(fmt.Sprintf("Failed to derive CKG CRP: %v", err))
+						reject.Invoke(errorObject)
+						return
+					}
+				} else if env.Seed != seed {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("round share %d used a different seed than share 0", i))
+					reject.Invoke(errorObject)
+					return
+				}
+
+				share := ckgProto.AllocateShare()
+				if err := share.UnmarshalBinary(env.Payload); err != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal round share %d: %v", i, err))
+					reject.Invoke(errorObject)
+					return
+				}
+
+				if i == 0 {
+					agg = share
+					continue
+				}
+				if err := ckgProto.AggregateShares(agg, share, &agg); err != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("Failed to aggregate round share %d: %v", i, err))
+					reject.Invoke(errorObject)
+					return
+				}
+			}
+
+			if threshold > 0 && numShares < threshold {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("only %d of %d required shares were provided", numShares, threshold))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			pk := rlwe.NewPublicKey(params)
+			ckgProto.GenPublicKey(agg, crp, pk)
+
+			pkBytes, err := pk.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal collective public key: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			pkArray := js.Global().Get("Uint8Array").New(len(pkBytes))
+			js.CopyBytesToJS(pkArray, pkBytes)
+			resolve.Invoke(pkArray)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
+
+// mpcSmudgingNoise is the extra Gaussian noise CKSProtocol adds on top of
+// each party's share so the aggregated share alone doesn't leak more than
+// the plaintext (standard practice for collective decryption to skOut=0).
+const mpcSmudgingNoise = 1 << 30
+
+// fheMPCPartialDecryptWrapper: 이 파티의 비밀키 조각으로 ctxt를 부분 복호화
+// (ctxt, shareSk). Runs a CKS (collective key-switch) share toward the
+// all-zero output key, which is the standard way to turn key-switching into
+// threshold decryption: once every party's share is summed, what's left is
+// the plaintext (plus smudging noise) with no secret key attached at all.
+func fheMPCPartialDecryptWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return js.Global().Get("Error").New("fheMPCPartialDecrypt requires 2 arguments: ciphertext (Uint8Array), secretKeyShare (Uint8Array)")
+	}
+
+	ctArray := args[0]
+	skArray := args[1]
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("MPCPartialDecrypt failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			ctBytes := make([]byte, ctArray.Get("length").Int())
+			js.CopyBytesToGo(ctBytes, ctArray)
+			ct := &rlwe.Ciphertext{}
+			if err := ct.UnmarshalBinary(ctBytes); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			skEnvBytes := make([]byte, skArray.Get("length").Int())
+			js.CopyBytesToGo(skEnvBytes, skArray)
+			skEnv, err := unmarshalEnvelope(skEnvBytes)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to parse secret key share: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+			sk := &rlwe.SecretKey{}
+			if err := sk.UnmarshalBinary(skEnv.Payload); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal secret key share: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			cksProto := newMPCKeySwitchProtocol()
+			skZero := rlwe.NewSecretKey(params)
+
+			share := cksProto.AllocateShare(ct.Level())
+			if err := cksProto.GenShare(sk, skZero, ct, &share); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to generate partial decryption share: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			shareBytes, err := share.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal partial decryption share: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			// Only the party that generated shareSk with partyIndex 0 folds
+			// the ciphertext's own c0 component into its partial decryption
+			// (see fheMPCCombinePartialsWrapper); everyone else's share is
+			// just their additive contribution.
+			envelope := mpcEnvelope{PartyIndex: skEnv.PartyIndex, Payload: shareBytes}
+			if skEnv.PartyIndex == 0 {
+				ctBytesForCombine, err := ct.MarshalBinary()
+				if err != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal ciphertext for combine step: %v", err))
+					reject.Invoke(errorObject)
+					return
+				}
+				envelope.Seed = string(ctBytesForCombine)
+			}
+
+			envBytes, err := marshalEnvelope(envelope)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to build partial decryption envelope: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			envArray := js.Global().Get("Uint8Array").New(len(envBytes))
+			js.CopyBytesToJS(envArray, envBytes)
+			resolve.Invoke(envArray)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
+
+// fheMPCCombinePartialsWrapper: 부분 복호화들을 결합해 평문 획득 ([partialDec, ...]).
+func fheMPCCombinePartialsWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return js.Global().Get("Error").New("fheMPCCombinePartials requires 1 argument: partialDecryptions ([Uint8Array])")
+	}
+
+	partialsArg := args[0]
+	numPartials := partialsArg.Get("length").Int()
+	partialArrays := make([]js.Value, numPartials)
+	for i := 0; i < numPartials; i++ {
+		partialArrays[i] = partialsArg.Index(i)
+	}
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("MPCCombinePartials failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			if numPartials == 0 {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New("fheMPCCombinePartials requires at least one partial decryption")
+				reject.Invoke(errorObject)
+				return
+			}
+
+			cksProto := newMPCKeySwitchProtocol()
+
+			var ct *rlwe.Ciphertext
+			var agg multiparty.KeySwitchShare
+
+			for i, arr := range partialArrays {
+				envBytes := make([]byte, arr.Get("length").Int())
+				js.CopyBytesToGo(envBytes, arr)
+
+				env, err := unmarshalEnvelope(envBytes)
+				if err != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("Failed to parse partial decryption %d: %v", i, err))
+					reject.Invoke(errorObject)
+					return
+				}
+				if env.PartyIndex == 0 {
+					ct = &rlwe.Ciphertext{}
+					if err := ct.UnmarshalBinary([]byte(env.Seed)); err != nil {
+						errorConstructor := js.Global().Get("Error")
+						errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal ciphertext carried by partial decryption %d: %v", i, err))
+						reject.Invoke(errorObject)
+						return
+					}
+				}
+
+				share := cksProto.AllocateShare(0)
+				if err := share.UnmarshalBinary(env.Payload); err != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal partial decryption %d: %v", i, err))
+					reject.Invoke(errorObject)
+					return
+				}
+
+				if i == 0 {
+					agg = share
+					continue
+				}
+				if err := cksProto.AggregateShares(agg, share, &agg); err != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("Failed to aggregate partial decryption %d: %v", i, err))
+					reject.Invoke(errorObject)
+					return
+				}
+			}
+
+			if ct == nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New("no partial decryption carried the ciphertext (was party 0's share included?)")
+				reject.Invoke(errorObject)
+				return
+			}
+
+			ctOut := ckks.NewCiphertext(params, 1, ct.Level())
+			if err := cksProto.KeySwitch(ct, agg, ctOut); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to finalize collective decryption: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			// ctOut is now switched under the all-zero secret key, i.e. it's
+			// a trivial encryption of the plaintext plus smudging noise;
+			// decrypting with a zero-valued secret key just strips that
+			// trivial c1 term rather than needing any real key material.
+			skZero := rlwe.NewSecretKey(params)
+			decryptor := ckks.NewDecryptor(params, skZero)
+			pt := decryptor.DecryptNew(ctOut)
+
+			values := make([]complex128, params.MaxSlots())
+			if err := encoder.Decode(pt, values); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to decode collectively decrypted plaintext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			resolve.Invoke(js.ValueOf(real(values[0])))
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
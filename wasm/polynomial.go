@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"syscall/js"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// minimaxSigmoidCoeffs holds the same degree-3/7/15 minimax coefficients as
+// backend/sigmoid's MinimaxApprox and PSApprox tables (fit for sigmoid on
+// [-8, 8], ascending order, only odd powers non-zero). The wasm module has no
+// import path back to the backend module, so the tables are duplicated here
+// rather than shared, the same way repeatIntWasm duplicates cmd/benchmark's
+// repeatInt instead of importing across binaries.
+var minimaxSigmoidCoeffs = map[int][]float64{
+	3: {0.5, 0.2159198, 0.0, -0.0082176},
+	7: {0.5, 0.2471169, 0.0, -0.0195740, 0.0, 0.0015314, 0.0, -0.0000451},
+	15: {
+		0.5, 0.2494954, 0.0, -0.0204708, 0.0, 0.0012720, 0.0, -0.0000505,
+		0.0, 0.0000012, 0.0, -0.00000002, 0.0, 0.0, 0.0, 0.0,
+	},
+}
+
+// minimaxSigmoidDomain is the interval minimaxSigmoidCoeffs was fit on.
+var minimaxSigmoidDomain = [2]float64{-8, 8}
+
+// buildEvaluator deserializes rlkBytes and returns a *ckks.Evaluator that can
+// relinearize with it, the same pairing genRelinearizationKeyWrapper's
+// output is meant to be consumed with (rlwe.NewMemEvaluationKeySet(rlk) then
+// ckks.NewEvaluator), as already done in cmd/benchmark's softmax benchmark.
+func buildEvaluator(rlkBytes []byte) (*ckks.Evaluator, error) {
+	rlk := &rlwe.RelinearizationKey{}
+	if err := rlk.UnmarshalBinary(rlkBytes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal relinearization key: %v", err)
+	}
+	evk := rlwe.NewMemEvaluationKeySet(rlk)
+	return ckks.NewEvaluator(params, evk), nil
+}
+
+// evalMonomialPolynomial evaluates coeffs (ascending, c0 + c1*x + ...) on ct
+// using Lattigo's Paterson-Stockmeyer polynomial evaluator, following the
+// same bignum.Monomial construction CreditScoringApprox.Evaluate uses in
+// baseline/logn14/sigmoid/credit_sigmoid.go.
+func evalMonomialPolynomial(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, coeffs []float64) (*rlwe.Ciphertext, error) {
+	prec := uint(128)
+	bignumCoeffs := make([]*big.Float, len(coeffs))
+	for i, c := range coeffs {
+		bignumCoeffs[i] = bignum.NewFloat(c, prec)
+	}
+	poly := bignum.NewPolynomial(bignum.Monomial, bignumCoeffs, nil)
+
+	polyEval := polynomial.NewEvaluator(params, evaluator)
+	return polyEval.Evaluate(ct, polynomial.NewPolynomial(poly), params.DefaultScale())
+}
+
+// evalChebyshevPolynomial evaluates coeffs in the Chebyshev basis over
+// [interval[0], interval[1]], the basis Lattigo's polynomial evaluator uses
+// to keep evaluation numerically stable away from the domain's center;
+// callers fitting with backend/sigmoid's Chebyshev-Gauss quadrature
+// (quadrature.go's FitChebyshev) already produce coefficients in this basis.
+func evalChebyshevPolynomial(evaluator *ckks.Evaluator, ct *rlwe.Ciphertext, coeffs []float64, interval [2]float64) (*rlwe.Ciphertext, error) {
+	prec := uint(128)
+	bignumCoeffs := make([]*big.Float, len(coeffs))
+	for i, c := range coeffs {
+		bignumCoeffs[i] = bignum.NewFloat(c, prec)
+	}
+	chebyshevInterval := bignum.Interval{
+		A: bignum.NewFloat(interval[0], prec),
+		B: bignum.NewFloat(interval[1], prec),
+	}
+	poly := bignum.NewPolynomial(bignum.Chebyshev, bignumCoeffs, chebyshevInterval)
+
+	polyEval := polynomial.NewEvaluator(params, evaluator)
+	return polyEval.Evaluate(ct, polynomial.NewPolynomial(poly), params.DefaultScale())
+}
+
+// fheEvalPolynomialWrapper: 다항식(단항식 기저) 평가 (ciphertext, coeffsJSON, rlk) -> ciphertext
+// coeffsJSON is a JSON array of ascending-order monomial coefficients
+// (c0 + c1*x + c2*x^2 + ...), the same convention backend/sigmoid.FittedApprox
+// and CreditScoringApprox use, so a coefficient table fit for sigmoid, ReLU,
+// tanh, or a logistic-regression scorer can be plugged in directly.
+func fheEvalPolynomialWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 3 {
+		return js.Global().Get("Error").New("fheEvalPolynomial requires 3 arguments: ciphertext (Uint8Array), coeffs (JSON array), relinearizationKey (Uint8Array)")
+	}
+
+	ctArray := args[0]
+	coeffsJSON := args[1].String()
+	rlkArray := args[2]
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("EvalPolynomial failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			var coeffs []float64
+			if err := json.Unmarshal([]byte(coeffsJSON), &coeffs); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to parse coeffs: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			ctBytes := make([]byte, ctArray.Get("length").Int())
+			js.CopyBytesToGo(ctBytes, ctArray)
+			ct := &rlwe.Ciphertext{}
+			if err := ct.UnmarshalBinary(ctBytes); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			rlkBytes := make([]byte, rlkArray.Get("length").Int())
+			js.CopyBytesToGo(rlkBytes, rlkArray)
+			evaluator, err := buildEvaluator(rlkBytes)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(err.Error())
+				reject.Invoke(errorObject)
+				return
+			}
+
+			result, err := evalMonomialPolynomial(evaluator, ct, coeffs)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Polynomial evaluation failed: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			resultBytes, err := result.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal result ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			resultArray := js.Global().Get("Uint8Array").New(len(resultBytes))
+			js.CopyBytesToJS(resultArray, resultBytes)
+			resolve.Invoke(resultArray)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
+
+// fheEvalChebyshevWrapper: 체비셰프 기저 다항식 평가 (ciphertext, coeffsJSON, intervalJSON, rlk) -> ciphertext
+// intervalJSON is a 2-element JSON array [a, b] bounding the ciphertext's
+// plaintext values, the same interval backend/sigmoid's quadrature fitters
+// (NewChebyshevQuadratureApprox) fit their coefficients against.
+func fheEvalChebyshevWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 4 {
+		return js.Global().Get("Error").New("fheEvalChebyshev requires 4 arguments: ciphertext (Uint8Array), coeffs (JSON array), interval (JSON [a, b]), relinearizationKey (Uint8Array)")
+	}
+
+	ctArray := args[0]
+	coeffsJSON := args[1].String()
+	intervalJSON := args[2].String()
+	rlkArray := args[3]
+
+	handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		reject := promiseArgs[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errorConstructor := js.Global().Get("Error")
+					errorObject := errorConstructor.New(fmt.Sprintf("EvalChebyshev failed: %v", r))
+					reject.Invoke(errorObject)
+				}
+			}()
+
+			var coeffs []float64
+			if err := json.Unmarshal([]byte(coeffsJSON), &coeffs); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to parse coeffs: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			var interval [2]float64
+			if err := json.Unmarshal([]byte(intervalJSON), &interval); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to parse interval: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			ctBytes := make([]byte, ctArray.Get("length").Int())
+			js.CopyBytesToGo(ctBytes, ctArray)
+			ct := &rlwe.Ciphertext{}
+			if err := ct.UnmarshalBinary(ctBytes); err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to unmarshal ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			rlkBytes := make([]byte, rlkArray.Get("length").Int())
+			js.CopyBytesToGo(rlkBytes, rlkArray)
+			evaluator, err := buildEvaluator(rlkBytes)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(err.Error())
+				reject.Invoke(errorObject)
+				return
+			}
+
+			result, err := evalChebyshevPolynomial(evaluator, ct, coeffs, interval)
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Chebyshev evaluation failed: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			resultBytes, err := result.MarshalBinary()
+			if err != nil {
+				errorConstructor := js.Global().Get("Error")
+				errorObject := errorConstructor.New(fmt.Sprintf("Failed to marshal result ciphertext: %v", err))
+				reject.Invoke(errorObject)
+				return
+			}
+
+			resultArray := js.Global().Get("Uint8Array").New(len(resultBytes))
+			js.CopyBytesToJS(resultArray, resultBytes)
+			resolve.Invoke(resultArray)
+		}()
+
+		return nil
+	})
+
+	promiseConstructor := js.Global().Get("Promise")
+	return promiseConstructor.New(handler)
+}
+
+// fheMinimaxSigmoidWrapper: 사전 계산된 시그모이드 최소최대 계수 조회 (degree, intervalJSON) -> coeffsJSON
+// Returns one of minimaxSigmoidCoeffs's precomputed tables so the front end
+// can call fheEvalPolynomial without fitting its own coefficients. The
+// tables are fit on minimaxSigmoidDomain ([-8, 8]); they stay valid (just
+// non-optimal) on any narrower interval, so withinDomain reports whether the
+// caller's requested interval fits inside that fit domain rather than
+// silently refitting or failing.
+func fheMinimaxSigmoidWrapper(this js.Value, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return js.Global().Get("Error").New("fheMinimaxSigmoid requires 2 arguments: degree (number), interval (JSON [a, b])")
+	}
+
+	degree := args[0].Int()
+	var interval [2]float64
+	if err := json.Unmarshal([]byte(args[1].String()), &interval); err != nil {
+		return js.Global().Get("Error").New(fmt.Sprintf("Failed to parse interval: %v", err))
+	}
+
+	coeffs, ok := minimaxSigmoidCoeffs[degree]
+	if !ok {
+		return js.Global().Get("Error").New(fmt.Sprintf("fheMinimaxSigmoid: unsupported degree %d, expected 3, 7, or 15", degree))
+	}
+
+	result := map[string]interface{}{
+		"degree":       degree,
+		"coeffs":       coeffs,
+		"fitDomain":    minimaxSigmoidDomain,
+		"withinDomain": interval[0] >= minimaxSigmoidDomain[0] && interval[1] <= minimaxSigmoidDomain[1],
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return js.Global().Get("Error").New(fmt.Sprintf("Failed to marshal minimax coefficients: %v", err))
+	}
+
+	return js.ValueOf(string(jsonBytes))
+}